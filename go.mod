@@ -7,7 +7,10 @@ toolchain go1.24.0
 require (
 	github.com/gbloisi-openaire/airflow-client-go/airflow v0.0.0-20250627192802-a6e7f40e9b8e
 	github.com/gbloisi-openaire/airflow-client-go/auth v0.0.0-20250627192802-a6e7f40e9b8e
+	github.com/hashicorp/terraform-plugin-log v0.9.0
 	github.com/hashicorp/terraform-plugin-sdk/v2 v2.36.1
+	golang.org/x/oauth2 v0.30.0
+	golang.org/x/time v0.11.0
 )
 
 require (
@@ -34,7 +37,6 @@ require (
 	github.com/hashicorp/terraform-exec v0.22.0 // indirect
 	github.com/hashicorp/terraform-json v0.24.0 // indirect
 	github.com/hashicorp/terraform-plugin-go v0.26.0 // indirect
-	github.com/hashicorp/terraform-plugin-log v0.9.0 // indirect
 	github.com/hashicorp/terraform-registry-address v0.2.4 // indirect
 	github.com/hashicorp/terraform-svchost v0.1.1 // indirect
 	github.com/hashicorp/yamux v0.1.2 // indirect
@@ -53,7 +55,6 @@ require (
 	golang.org/x/crypto v0.35.0 // indirect
 	golang.org/x/mod v0.23.0 // indirect
 	golang.org/x/net v0.35.0 // indirect
-	golang.org/x/oauth2 v0.30.0 // indirect
 	golang.org/x/sync v0.11.0 // indirect
 	golang.org/x/sys v0.30.0 // indirect
 	golang.org/x/text v0.22.0 // indirect
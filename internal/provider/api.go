@@ -0,0 +1,120 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/gbloisi-openaire/airflow-client-go/airflow"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	// apiVersionV1 is the stable REST API of Airflow 2, served under /api/v1.
+	apiVersionV1 = "v1"
+	// apiVersionV2 is the REST API of Airflow 3, served under /api/v2.
+	apiVersionV2 = "v2"
+)
+
+// airflowAPI is the part of the Airflow REST API the resources use. It is
+// implemented for both major versions of Airflow, so that the resources do not
+// depend on the version they talk to. Entities are exchanged as the types of
+// the generated Airflow 3 client.
+//
+// Lookups report missing entities as not found rather than as errors. They
+// may be served from a listing of the whole collection, which pays off when
+// refreshing many resources, while Gets read the single entity directly, as
+// fits data sources. Writes
+// return the response, when there is one, so that callers can inspect the
+// status code of a failed request.
+type airflowAPI interface {
+	GetVersion(ctx context.Context) (*airflow.VersionInfo, *http.Response, error)
+	GetHealth(ctx context.Context) (*airflow.HealthInfoResponse, *http.Response, error)
+
+	LookupVariable(ctx context.Context, key string) (*airflow.VariableResponse, bool, error)
+	GetVariable(ctx context.Context, key string) (*airflow.VariableResponse, bool, error)
+	CreateVariable(ctx context.Context, variable airflow.VariableBody) (*http.Response, error)
+	UpdateVariable(ctx context.Context, key string, variable airflow.VariableBody) (*http.Response, error)
+	DeleteVariable(ctx context.Context, key string) (*http.Response, error)
+
+	LookupConnection(ctx context.Context, connId string) (*airflow.ConnectionResponse, bool, error)
+	GetConnection(ctx context.Context, connId string) (*airflow.ConnectionResponse, bool, error)
+	CreateConnection(ctx context.Context, conn airflow.ConnectionBody) (*http.Response, error)
+	UpdateConnection(ctx context.Context, connId string, conn airflow.ConnectionBody) (*http.Response, error)
+	DeleteConnection(ctx context.Context, connId string) (*http.Response, error)
+
+	LookupPool(ctx context.Context, name string) (*airflow.PoolResponse, bool, error)
+	GetPool(ctx context.Context, name string) (*airflow.PoolResponse, bool, error)
+	CreatePool(ctx context.Context, pool airflow.PoolBody) (*http.Response, error)
+	UpdatePoolSlots(ctx context.Context, name string, slots int32) (*http.Response, error)
+	DeletePool(ctx context.Context, name string) (*http.Response, error)
+
+	GetDag(ctx context.Context, dagId string) (*airflow.DAGResponse, *http.Response, error)
+	PatchDag(ctx context.Context, dagId string, isPaused bool) (*http.Response, error)
+	DeleteDag(ctx context.Context, dagId string) (*http.Response, error)
+
+	TriggerDagRun(ctx context.Context, dagId, dagRunId string, conf map[string]interface{}) (*airflow.DAGRunResponse, *http.Response, error)
+	GetDagRun(ctx context.Context, dagId, dagRunId string) (*airflow.DAGRunResponse, *http.Response, error)
+	DeleteDagRun(ctx context.Context, dagId, dagRunId string) (*http.Response, error)
+}
+
+// detectAPIVersion finds out which major version of Airflow serves endpoint,
+// trying the API of Airflow 3 first, and returns the version information when
+// the server discloses it. The version endpoints of both APIs do not require
+// authentication, so this runs before the provider logs in.
+func detectAPIVersion(ctx context.Context, httpClient *http.Client, endpoint string) (string, *airflow.VersionInfo, error) {
+	endpoint = strings.TrimSuffix(endpoint, "/")
+
+	for _, apiVersion := range []string{apiVersionV2, apiVersionV1} {
+		client := &restClient{
+			httpClient: httpClient,
+			basePath:   endpoint + "/api/" + apiVersion,
+		}
+
+		info := v1Version{}
+		resp, err := client.do(ctx, http.MethodGet, "/version", nil, nil, &info)
+		if resp == nil {
+			return "", nil, fmt.Errorf("failed to get the version of Airflow: %w", err)
+		}
+		if resp.StatusCode == 404 {
+			continue
+		}
+		if err != nil {
+			// The API exists, the server may just protect its version.
+			log.Printf("[WARN] Failed to get the version of Airflow, assuming API %s: %s", apiVersion, err)
+			return apiVersion, nil, nil
+		}
+
+		return apiVersion, info.toResponse(), nil
+	}
+
+	return "", nil, fmt.Errorf("no Airflow API found at %s", endpoint)
+}
+
+// apiV2Only makes a data source fail with a clear error on Airflow 2, for
+// those relying on endpoints only the Airflow 3 API has.
+func apiV2Only(r *schema.Resource) *schema.Resource {
+	read := r.ReadWithoutTimeout
+	r.ReadWithoutTimeout = func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		if pcfg := m.(ProviderConfig); pcfg.APIVersion != apiVersionV2 {
+			return diag.Errorf("this data source requires Airflow 3, but the provider talks to the /api/%s API of Airflow 2", pcfg.APIVersion)
+		}
+
+		return read(ctx, d, m)
+	}
+
+	return r
+}
+
+// responseStatus returns the status of resp for error messages, there is no
+// response when the request failed before reaching Airflow.
+func responseStatus(resp *http.Response) string {
+	if resp == nil {
+		return ""
+	}
+
+	return resp.Status
+}
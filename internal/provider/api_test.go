@@ -0,0 +1,161 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestProviderConfigure_detectsAirflow2(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/api/v1/version":
+			w.Write([]byte(`{"version":"2.10.5","git_version":null}`))
+		case "/api/v1/variables/example":
+			if r.Header.Get("Authorization") != "Bearer token" {
+				t.Errorf("expected the token to be sent, got %q", r.Header.Get("Authorization"))
+			}
+			w.Write([]byte(`{"key":"example","value":"value","description":"from Airflow 2"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"detail":"Not Found"}`))
+		}
+	}))
+	defer server.Close()
+
+	d := schema.TestResourceDataRaw(t, AirflowProvider("test").Schema, map[string]interface{}{
+		"base_endpoint": server.URL,
+		"oauth2_token":  "token",
+	})
+	meta, diags := providerConfigure(context.Background(), d, "")
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	pcfg := meta.(ProviderConfig)
+
+	if pcfg.APIVersion != apiVersionV1 || pcfg.AirflowVersion.Version != "2.10.5" {
+		t.Fatalf("expected API v1 of Airflow 2.10.5, got %s and %v", pcfg.APIVersion, pcfg.AirflowVersion)
+	}
+	if _, ok := pcfg.API.(*apiV1); !ok {
+		t.Fatalf("expected the API v1 implementation, got %T", pcfg.API)
+	}
+	if !strings.HasSuffix(pcfg.FabClient.basePath, "/api/v1") {
+		t.Fatalf("expected the FAB endpoints under /api/v1, got %s", pcfg.FabClient.basePath)
+	}
+
+	variable, found, err := pcfg.API.LookupVariable(pcfg.AuthContext, "example")
+	if err != nil || !found {
+		t.Fatalf("expected the variable to be found, got %t %v", found, err)
+	}
+	if variable.Value != "value" || variable.GetDescription() != "from Airflow 2" {
+		t.Fatalf("unexpected variable %v", variable)
+	}
+
+	if _, found, err := pcfg.API.LookupVariable(pcfg.AuthContext, "missing"); err != nil || found {
+		t.Fatalf("expected a missing variable not to be found, got %t %v", found, err)
+	}
+
+	diags = apiV2Only(dataSourceDags()).ReadWithoutTimeout(context.Background(), dataSourceDags().TestResourceData(), meta)
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "requires Airflow 3") {
+		t.Fatalf("expected data sources to require Airflow 3, got %v", diags)
+	}
+}
+
+func TestDataSources_airflow2(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/api/v1/version":
+			w.Write([]byte(`{"version":"2.10.5","git_version":".release:abc"}`))
+		case "/api/v1/health":
+			w.Write([]byte(`{"metadatabase":{"status":"healthy"},"scheduler":{"status":"healthy","latest_scheduler_heartbeat":"2025-01-01T00:00:00+00:00"},"triggerer":{"status":null,"latest_triggerer_heartbeat":null}}`))
+		case "/api/v1/pools/default_pool":
+			w.Write([]byte(`{"name":"default_pool","slots":128,"occupied_slots":2,"used_slots":1,"queued_slots":1,"open_slots":126,"scheduled_slots":0,"deferred_slots":0,"description":null,"include_deferred":false}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"detail":"Not Found"}`))
+		}
+	}))
+	defer server.Close()
+
+	d := schema.TestResourceDataRaw(t, AirflowProvider("test").Schema, map[string]interface{}{
+		"base_endpoint": server.URL,
+		"oauth2_token":  "token",
+	})
+	meta, diags := providerConfigure(context.Background(), d, "")
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	dataSources := AirflowProvider("test").DataSourcesMap
+
+	version := dataSources["airflow_version"].TestResourceData()
+	if diags := dataSources["airflow_version"].ReadWithoutTimeout(context.Background(), version, meta); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if version.Get("major").(int) != 2 || version.Get("git_version").(string) != ".release:abc" {
+		t.Fatalf("unexpected version %v %v", version.Get("major"), version.Get("git_version"))
+	}
+
+	health := dataSources["airflow_health"].TestResourceData()
+	if diags := dataSources["airflow_health"].ReadWithoutTimeout(context.Background(), health, meta); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if health.Get("scheduler_status").(string) != "healthy" || health.Get("dag_processor_status").(string) != "" {
+		t.Fatalf("unexpected health %v %v", health.Get("scheduler_status"), health.Get("dag_processor_status"))
+	}
+
+	pool := dataSources["airflow_pool"].TestResourceData()
+	pool.Set("name", "default_pool")
+	if diags := dataSources["airflow_pool"].ReadWithoutTimeout(context.Background(), pool, meta); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if pool.Get("used_slots").(int) != 1 || pool.Get("open_slots").(int) != 126 {
+		t.Fatalf("unexpected pool slots %v %v", pool.Get("used_slots"), pool.Get("open_slots"))
+	}
+
+	variable := dataSources["airflow_variable"].TestResourceData()
+	variable.Set("key", "missing")
+	diags = dataSources["airflow_variable"].ReadWithoutTimeout(context.Background(), variable, meta)
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "not found") {
+		t.Fatalf("expected a missing variable to be reported, got %v", diags)
+	}
+}
+
+func TestProviderConfigure_detectsAirflow3(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v2/version" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"version":"3.0.2","git_version":null}`))
+	}))
+	defer server.Close()
+
+	d := schema.TestResourceDataRaw(t, AirflowProvider("test").Schema, map[string]interface{}{
+		"base_endpoint": server.URL,
+		"oauth2_token":  "token",
+	})
+	meta, diags := providerConfigure(context.Background(), d, "")
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	pcfg := meta.(ProviderConfig)
+
+	if pcfg.APIVersion != apiVersionV2 || pcfg.AirflowVersion.Version != "3.0.2" {
+		t.Fatalf("expected API v2 of Airflow 3.0.2, got %s and %v", pcfg.APIVersion, pcfg.AirflowVersion)
+	}
+	if _, ok := pcfg.API.(*apiV2); !ok {
+		t.Fatalf("expected the API v2 implementation, got %T", pcfg.API)
+	}
+	if !strings.HasSuffix(pcfg.FabClient.basePath, "/auth/fab/v1") {
+		t.Fatalf("expected the FAB endpoints under /auth/fab/v1, got %s", pcfg.FabClient.basePath)
+	}
+}
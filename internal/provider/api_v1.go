@@ -0,0 +1,342 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/gbloisi-openaire/airflow-client-go/airflow"
+)
+
+// apiV1 implements airflowAPI on the stable REST API of Airflow 2. The
+// generated client only covers Airflow 3, so it sends the requests itself and
+// converts the responses to the types of the generated client. The API has no
+// bulk endpoints, every write is a request of its own.
+type apiV1 struct {
+	rest *restClient
+}
+
+type v1Version struct {
+	Version    string  `json:"version"`
+	GitVersion *string `json:"git_version"`
+}
+
+type v1Health struct {
+	Metadatabase struct {
+		Status *string `json:"status"`
+	} `json:"metadatabase"`
+	Scheduler struct {
+		Status                   *string `json:"status"`
+		LatestSchedulerHeartbeat *string `json:"latest_scheduler_heartbeat"`
+	} `json:"scheduler"`
+	Triggerer struct {
+		Status                   *string `json:"status"`
+		LatestTriggererHeartbeat *string `json:"latest_triggerer_heartbeat"`
+	} `json:"triggerer"`
+	DagProcessor *struct {
+		Status                      *string `json:"status"`
+		LatestDagProcessorHeartbeat *string `json:"latest_dag_processor_heartbeat"`
+	} `json:"dag_processor"`
+}
+
+type v1Variable struct {
+	Key         string  `json:"key"`
+	Value       string  `json:"value"`
+	Description *string `json:"description"`
+}
+
+type v1Connection struct {
+	ConnectionId string  `json:"connection_id"`
+	ConnType     string  `json:"conn_type"`
+	Description  *string `json:"description"`
+	Host         *string `json:"host"`
+	Login        *string `json:"login"`
+	Schema       *string `json:"schema"`
+	Port         *int32  `json:"port"`
+	Extra        *string `json:"extra"`
+}
+
+type v1Pool struct {
+	Name            string  `json:"name"`
+	Slots           int32   `json:"slots"`
+	OccupiedSlots   int32   `json:"occupied_slots"`
+	UsedSlots       int32   `json:"used_slots"`
+	QueuedSlots     int32   `json:"queued_slots"`
+	OpenSlots       int32   `json:"open_slots"`
+	ScheduledSlots  int32   `json:"scheduled_slots"`
+	DeferredSlots   int32   `json:"deferred_slots"`
+	Description     *string `json:"description"`
+	IncludeDeferred bool    `json:"include_deferred"`
+}
+
+type v1Dag struct {
+	DagId       string  `json:"dag_id"`
+	IsPaused    bool    `json:"is_paused"`
+	IsActive    bool    `json:"is_active"`
+	Description *string `json:"description"`
+	FileToken   string  `json:"file_token"`
+	Fileloc     string  `json:"fileloc"`
+}
+
+type v1DagRun struct {
+	DagRunId string                 `json:"dag_run_id"`
+	DagId    string                 `json:"dag_id"`
+	State    string                 `json:"state"`
+	Conf     map[string]interface{} `json:"conf"`
+}
+
+func newAPIV1(httpClient *http.Client, basePath string) *apiV1 {
+	return &apiV1{
+		rest: &restClient{
+			httpClient: httpClient,
+			basePath:   basePath,
+		},
+	}
+}
+
+// lookup gets path into out, a 404 is reported as not found.
+func (a *apiV1) lookup(ctx context.Context, path string, out interface{}) (bool, error) {
+	resp, err := a.rest.do(ctx, http.MethodGet, path, nil, nil, out)
+	if resp != nil && resp.StatusCode == 404 {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (a *apiV1) GetVersion(ctx context.Context) (*airflow.VersionInfo, *http.Response, error) {
+	version := v1Version{}
+	resp, err := a.rest.do(ctx, http.MethodGet, "/version", nil, nil, &version)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return version.toResponse(), resp, nil
+}
+
+func (a *apiV1) GetHealth(ctx context.Context) (*airflow.HealthInfoResponse, *http.Response, error) {
+	health := v1Health{}
+	resp, err := a.rest.do(ctx, http.MethodGet, "/health", nil, nil, &health)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return health.toResponse(), resp, nil
+}
+
+func (a *apiV1) LookupVariable(ctx context.Context, key string) (*airflow.VariableResponse, bool, error) {
+	v := v1Variable{}
+	found, err := a.lookup(ctx, "/variables/"+url.PathEscape(key), &v)
+	if !found || err != nil {
+		return nil, found, err
+	}
+
+	return &airflow.VariableResponse{
+		Key:         v.Key,
+		Value:       v.Value,
+		Description: *airflow.NewNullableString(v.Description),
+	}, true, nil
+}
+
+// GetVariable is LookupVariable, there is no read cache on Airflow 2.
+func (a *apiV1) GetVariable(ctx context.Context, key string) (*airflow.VariableResponse, bool, error) {
+	return a.LookupVariable(ctx, key)
+}
+
+func (a *apiV1) CreateVariable(ctx context.Context, variable airflow.VariableBody) (*http.Response, error) {
+	return a.rest.do(ctx, http.MethodPost, "/variables", nil, variable, nil)
+}
+
+func (a *apiV1) UpdateVariable(ctx context.Context, key string, variable airflow.VariableBody) (*http.Response, error) {
+	return a.rest.do(ctx, http.MethodPatch, "/variables/"+url.PathEscape(key), nil, variable, nil)
+}
+
+func (a *apiV1) DeleteVariable(ctx context.Context, key string) (*http.Response, error) {
+	return a.rest.do(ctx, http.MethodDelete, "/variables/"+url.PathEscape(key), nil, nil, nil)
+}
+
+func (a *apiV1) LookupConnection(ctx context.Context, connId string) (*airflow.ConnectionResponse, bool, error) {
+	c := v1Connection{}
+	found, err := a.lookup(ctx, "/connections/"+url.PathEscape(connId), &c)
+	if !found || err != nil {
+		return nil, found, err
+	}
+
+	// The password is write only in the API of Airflow 2, it stays unset.
+	return &airflow.ConnectionResponse{
+		ConnectionId: c.ConnectionId,
+		ConnType:     c.ConnType,
+		Description:  *airflow.NewNullableString(c.Description),
+		Host:         *airflow.NewNullableString(c.Host),
+		Login:        *airflow.NewNullableString(c.Login),
+		Schema:       *airflow.NewNullableString(c.Schema),
+		Port:         *airflow.NewNullableInt32(c.Port),
+		Extra:        *airflow.NewNullableString(c.Extra),
+	}, true, nil
+}
+
+// GetConnection is LookupConnection, there is no read cache on Airflow 2.
+func (a *apiV1) GetConnection(ctx context.Context, connId string) (*airflow.ConnectionResponse, bool, error) {
+	return a.LookupConnection(ctx, connId)
+}
+
+func (a *apiV1) CreateConnection(ctx context.Context, conn airflow.ConnectionBody) (*http.Response, error) {
+	return a.rest.do(ctx, http.MethodPost, "/connections", nil, conn, nil)
+}
+
+func (a *apiV1) UpdateConnection(ctx context.Context, connId string, conn airflow.ConnectionBody) (*http.Response, error) {
+	return a.rest.do(ctx, http.MethodPatch, "/connections/"+url.PathEscape(connId), nil, conn, nil)
+}
+
+func (a *apiV1) DeleteConnection(ctx context.Context, connId string) (*http.Response, error) {
+	return a.rest.do(ctx, http.MethodDelete, "/connections/"+url.PathEscape(connId), nil, nil, nil)
+}
+
+func (a *apiV1) LookupPool(ctx context.Context, name string) (*airflow.PoolResponse, bool, error) {
+	p := v1Pool{}
+	found, err := a.lookup(ctx, "/pools/"+url.PathEscape(name), &p)
+	if !found || err != nil {
+		return nil, found, err
+	}
+
+	return &airflow.PoolResponse{
+		Name:            p.Name,
+		Slots:           p.Slots,
+		Description:     *airflow.NewNullableString(p.Description),
+		IncludeDeferred: p.IncludeDeferred,
+		OccupiedSlots:   p.OccupiedSlots,
+		RunningSlots:    p.UsedSlots,
+		QueuedSlots:     p.QueuedSlots,
+		ScheduledSlots:  p.ScheduledSlots,
+		OpenSlots:       p.OpenSlots,
+		DeferredSlots:   p.DeferredSlots,
+	}, true, nil
+}
+
+// GetPool is LookupPool, there is no read cache on Airflow 2.
+func (a *apiV1) GetPool(ctx context.Context, name string) (*airflow.PoolResponse, bool, error) {
+	return a.LookupPool(ctx, name)
+}
+
+func (a *apiV1) CreatePool(ctx context.Context, pool airflow.PoolBody) (*http.Response, error) {
+	return a.rest.do(ctx, http.MethodPost, "/pools", nil, pool, nil)
+}
+
+func (a *apiV1) UpdatePoolSlots(ctx context.Context, name string, slots int32) (*http.Response, error) {
+	pool := map[string]interface{}{
+		"name":  name,
+		"slots": slots,
+	}
+
+	return a.rest.do(ctx, http.MethodPatch, "/pools/"+url.PathEscape(name), url.Values{"update_mask": {"slots"}}, pool, nil)
+}
+
+func (a *apiV1) DeletePool(ctx context.Context, name string) (*http.Response, error) {
+	return a.rest.do(ctx, http.MethodDelete, "/pools/"+url.PathEscape(name), nil, nil, nil)
+}
+
+func (a *apiV1) GetDag(ctx context.Context, dagId string) (*airflow.DAGResponse, *http.Response, error) {
+	dag := v1Dag{}
+	resp, err := a.rest.do(ctx, http.MethodGet, "/dags/"+url.PathEscape(dagId), nil, nil, &dag)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &airflow.DAGResponse{
+		DagId:       dag.DagId,
+		IsPaused:    dag.IsPaused,
+		IsStale:     !dag.IsActive,
+		Description: *airflow.NewNullableString(dag.Description),
+		FileToken:   dag.FileToken,
+		Fileloc:     dag.Fileloc,
+	}, resp, nil
+}
+
+func (a *apiV1) PatchDag(ctx context.Context, dagId string, isPaused bool) (*http.Response, error) {
+	dag := map[string]interface{}{
+		"is_paused": isPaused,
+	}
+
+	return a.rest.do(ctx, http.MethodPatch, "/dags/"+url.PathEscape(dagId), nil, dag, nil)
+}
+
+func (a *apiV1) DeleteDag(ctx context.Context, dagId string) (*http.Response, error) {
+	return a.rest.do(ctx, http.MethodDelete, "/dags/"+url.PathEscape(dagId), nil, nil, nil)
+}
+
+func (a *apiV1) TriggerDagRun(ctx context.Context, dagId, dagRunId string, conf map[string]interface{}) (*airflow.DAGRunResponse, *http.Response, error) {
+	body := map[string]interface{}{}
+	if dagRunId != "" {
+		body["dag_run_id"] = dagRunId
+	}
+	if conf != nil {
+		body["conf"] = conf
+	}
+
+	dagRun := v1DagRun{}
+	resp, err := a.rest.do(ctx, http.MethodPost, "/dags/"+url.PathEscape(dagId)+"/dagRuns", nil, body, &dagRun)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return dagRun.toResponse(), resp, nil
+}
+
+func (a *apiV1) GetDagRun(ctx context.Context, dagId, dagRunId string) (*airflow.DAGRunResponse, *http.Response, error) {
+	dagRun := v1DagRun{}
+	resp, err := a.rest.do(ctx, http.MethodGet, "/dags/"+url.PathEscape(dagId)+"/dagRuns/"+url.PathEscape(dagRunId), nil, nil, &dagRun)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return dagRun.toResponse(), resp, nil
+}
+
+func (a *apiV1) DeleteDagRun(ctx context.Context, dagId, dagRunId string) (*http.Response, error) {
+	return a.rest.do(ctx, http.MethodDelete, "/dags/"+url.PathEscape(dagId)+"/dagRuns/"+url.PathEscape(dagRunId), nil, nil, nil)
+}
+
+func (r v1DagRun) toResponse() *airflow.DAGRunResponse {
+	return &airflow.DAGRunResponse{
+		DagRunId: r.DagRunId,
+		DagId:    r.DagId,
+		State:    airflow.DagRunState(r.State),
+		Conf:     r.Conf,
+	}
+}
+
+func (v v1Version) toResponse() *airflow.VersionInfo {
+	return &airflow.VersionInfo{
+		Version:    v.Version,
+		GitVersion: *airflow.NewNullableString(v.GitVersion),
+	}
+}
+
+func (h v1Health) toResponse() *airflow.HealthInfoResponse {
+	health := &airflow.HealthInfoResponse{
+		Metadatabase: airflow.BaseInfoResponse{
+			Status: *airflow.NewNullableString(h.Metadatabase.Status),
+		},
+		Scheduler: airflow.SchedulerInfoResponse{
+			Status:                   *airflow.NewNullableString(h.Scheduler.Status),
+			LatestSchedulerHeartbeat: *airflow.NewNullableString(h.Scheduler.LatestSchedulerHeartbeat),
+		},
+		Triggerer: airflow.TriggererInfoResponse{
+			Status:                   *airflow.NewNullableString(h.Triggerer.Status),
+			LatestTriggererHeartbeat: *airflow.NewNullableString(h.Triggerer.LatestTriggererHeartbeat),
+		},
+	}
+
+	// Only reported when the DAG processor runs standalone.
+	if h.DagProcessor != nil {
+		health.SetDagProcessor(airflow.DagProcessorInfoResponse{
+			Status:                      *airflow.NewNullableString(h.DagProcessor.Status),
+			LatestDagProcessorHeartbeat: *airflow.NewNullableString(h.DagProcessor.LatestDagProcessorHeartbeat),
+		})
+	}
+
+	return health
+}
@@ -0,0 +1,225 @@
+package provider
+
+import (
+	"context"
+	"log"
+	"net/http"
+
+	"github.com/gbloisi-openaire/airflow-client-go/airflow"
+)
+
+// apiV2 implements airflowAPI on top of the generated client. Reads of
+// variables, connections and pools go through the read cache, and their
+// creations and deletions through the bulk endpoints.
+type apiV2 struct {
+	client  *airflow.APIClient
+	cache   *readCache
+	batcher *writeBatcher
+}
+
+func newAPIV2(client *airflow.APIClient) *apiV2 {
+	return &apiV2{
+		client:  client,
+		cache:   newReadCache(client),
+		batcher: newWriteBatcher(client),
+	}
+}
+
+func (a *apiV2) GetVersion(ctx context.Context) (*airflow.VersionInfo, *http.Response, error) {
+	return a.client.VersionAPI.GetVersion(ctx).Execute()
+}
+
+func (a *apiV2) GetHealth(ctx context.Context) (*airflow.HealthInfoResponse, *http.Response, error) {
+	return a.client.MonitorAPI.GetHealth(ctx).Execute()
+}
+
+func (a *apiV2) LookupVariable(ctx context.Context, key string) (*airflow.VariableResponse, bool, error) {
+	return a.cache.variables.lookup(ctx, key)
+}
+
+func (a *apiV2) GetVariable(ctx context.Context, key string) (*airflow.VariableResponse, bool, error) {
+	return a.cache.variables.fetch(ctx, key)
+}
+
+func (a *apiV2) CreateVariable(ctx context.Context, variable airflow.VariableBody) (*http.Response, error) {
+	a.cache.variables.invalidate(variable.Key)
+
+	// Concurrent creations share a bulk request, whatever it did not apply
+	// goes through the single variable endpoint.
+	bulkErr := a.batcher.variables.create(ctx, variable.Key, variable)
+	if bulkErr == nil {
+		return nil, nil
+	}
+	log.Printf("[DEBUG] Creating variable `%s` on its own: %s", variable.Key, bulkErr)
+
+	_, resp, err := a.client.VariableAPI.PostVariable(ctx).VariableBody(variable).Execute()
+	return resp, err
+}
+
+func (a *apiV2) UpdateVariable(ctx context.Context, key string, variable airflow.VariableBody) (*http.Response, error) {
+	a.cache.variables.invalidate(key)
+
+	bulkErr := a.batcher.variables.update(ctx, key, variable)
+	if bulkErr == nil {
+		return nil, nil
+	}
+	log.Printf("[DEBUG] Updating variable `%s` on its own: %s", key, bulkErr)
+
+	_, resp, err := a.client.VariableAPI.PatchVariable(ctx, key).VariableBody(variable).Execute()
+	return resp, err
+}
+
+func (a *apiV2) DeleteVariable(ctx context.Context, key string) (*http.Response, error) {
+	a.cache.variables.invalidate(key)
+
+	bulkErr := a.batcher.variables.delete(ctx, key)
+	if bulkErr == nil {
+		return nil, nil
+	}
+	log.Printf("[DEBUG] Deleting variable `%s` on its own: %s", key, bulkErr)
+
+	return a.client.VariableAPI.DeleteVariable(ctx, key).Execute()
+}
+
+func (a *apiV2) LookupConnection(ctx context.Context, connId string) (*airflow.ConnectionResponse, bool, error) {
+	return a.cache.connections.lookup(ctx, connId)
+}
+
+func (a *apiV2) GetConnection(ctx context.Context, connId string) (*airflow.ConnectionResponse, bool, error) {
+	return a.cache.connections.fetch(ctx, connId)
+}
+
+func (a *apiV2) CreateConnection(ctx context.Context, conn airflow.ConnectionBody) (*http.Response, error) {
+	a.cache.connections.invalidate(conn.ConnectionId)
+
+	// Concurrent creations share a bulk request, whatever it did not apply
+	// goes through the single connection endpoint.
+	bulkErr := a.batcher.connections.create(ctx, conn.ConnectionId, conn)
+	if bulkErr == nil {
+		return nil, nil
+	}
+	log.Printf("[DEBUG] Creating connection `%s` on its own: %s", conn.ConnectionId, bulkErr)
+
+	_, resp, err := a.client.ConnectionAPI.PostConnection(ctx).ConnectionBody(conn).Execute()
+	return resp, err
+}
+
+func (a *apiV2) UpdateConnection(ctx context.Context, connId string, conn airflow.ConnectionBody) (*http.Response, error) {
+	a.cache.connections.invalidate(connId)
+
+	// Bulk updates replace the whole connection, so only those carrying the
+	// password are batched. Without one, the single connection endpoint keeps
+	// the password set outside of Terraform.
+	if conn.HasPassword() {
+		bulkErr := a.batcher.connections.update(ctx, connId, conn)
+		if bulkErr == nil {
+			return nil, nil
+		}
+		log.Printf("[DEBUG] Updating connection `%s` on its own: %s", connId, bulkErr)
+	}
+
+	_, resp, err := a.client.ConnectionAPI.PatchConnection(ctx, connId).ConnectionBody(conn).Execute()
+	return resp, err
+}
+
+func (a *apiV2) DeleteConnection(ctx context.Context, connId string) (*http.Response, error) {
+	a.cache.connections.invalidate(connId)
+
+	bulkErr := a.batcher.connections.delete(ctx, connId)
+	if bulkErr == nil {
+		return nil, nil
+	}
+	log.Printf("[DEBUG] Deleting connection `%s` on its own: %s", connId, bulkErr)
+
+	return a.client.ConnectionAPI.DeleteConnection(ctx, connId).Execute()
+}
+
+func (a *apiV2) LookupPool(ctx context.Context, name string) (*airflow.PoolResponse, bool, error) {
+	return a.cache.pools.lookup(ctx, name)
+}
+
+func (a *apiV2) GetPool(ctx context.Context, name string) (*airflow.PoolResponse, bool, error) {
+	return a.cache.pools.fetch(ctx, name)
+}
+
+func (a *apiV2) CreatePool(ctx context.Context, pool airflow.PoolBody) (*http.Response, error) {
+	a.cache.pools.invalidate(pool.Name)
+
+	// Concurrent creations share a bulk request, whatever it did not apply
+	// goes through the single pool endpoint.
+	bulkErr := a.batcher.pools.create(ctx, pool.Name, pool)
+	if bulkErr == nil {
+		return nil, nil
+	}
+	log.Printf("[DEBUG] Creating pool `%s` on its own: %s", pool.Name, bulkErr)
+
+	_, resp, err := a.client.PoolAPI.PostPool(ctx).PoolBody(pool).Execute()
+	return resp, err
+}
+
+func (a *apiV2) UpdatePoolSlots(ctx context.Context, name string, slots int32) (*http.Response, error) {
+	a.cache.pools.invalidate(name)
+
+	pool := airflow.PoolPatchBody{
+		Slots: *airflow.NewNullableInt32(&slots),
+	}
+
+	// Updates are not batched: bulk updates replace the whole pool, which
+	// would reset the description and include_deferred flag set outside of
+	// Terraform, while this only patches the slots.
+	_, resp, err := a.client.PoolAPI.PatchPool(ctx, name).PoolPatchBody(pool).UpdateMask([]string{"slots"}).Execute()
+	return resp, err
+}
+
+func (a *apiV2) DeletePool(ctx context.Context, name string) (*http.Response, error) {
+	a.cache.pools.invalidate(name)
+
+	bulkErr := a.batcher.pools.delete(ctx, name)
+	if bulkErr == nil {
+		return nil, nil
+	}
+	log.Printf("[DEBUG] Deleting pool `%s` on its own: %s", name, bulkErr)
+
+	return a.client.PoolAPI.DeletePool(ctx, name).Execute()
+}
+
+func (a *apiV2) GetDag(ctx context.Context, dagId string) (*airflow.DAGResponse, *http.Response, error) {
+	return a.client.DAGAPI.GetDag(ctx, dagId).Execute()
+}
+
+func (a *apiV2) PatchDag(ctx context.Context, dagId string, isPaused bool) (*http.Response, error) {
+	dag := airflow.DAGPatchBody{
+		IsPaused: isPaused,
+	}
+
+	_, resp, err := a.client.DAGAPI.PatchDag(ctx, dagId).DAGPatchBody(dag).Execute()
+	return resp, err
+}
+
+func (a *apiV2) DeleteDag(ctx context.Context, dagId string) (*http.Response, error) {
+	_, resp, err := a.client.DAGAPI.DeleteDag(ctx, dagId).Execute()
+	return resp, err
+}
+
+func (a *apiV2) TriggerDagRun(ctx context.Context, dagId, dagRunId string, conf map[string]interface{}) (*airflow.DAGRunResponse, *http.Response, error) {
+	dagRun := *airflow.NewTriggerDAGRunPostBodyWithDefaults()
+
+	if dagRunId != "" {
+		dagRun.SetDagRunId(airflow.DagRunId{
+			String: &dagRunId})
+	}
+
+	if conf != nil {
+		dagRun.SetConf(conf)
+	}
+
+	return a.client.DagRunAPI.TriggerDagRun(ctx, dagId).TriggerDAGRunPostBody(dagRun).Execute()
+}
+
+func (a *apiV2) GetDagRun(ctx context.Context, dagId, dagRunId string) (*airflow.DAGRunResponse, *http.Response, error) {
+	return a.client.DagRunAPI.GetDagRun(ctx, dagId, dagRunId).Execute()
+}
+
+func (a *apiV2) DeleteDagRun(ctx context.Context, dagId, dagRunId string) (*http.Response, error) {
+	return a.client.DagRunAPI.DeleteDagRun(ctx, dagId, dagRunId).Execute()
+}
@@ -0,0 +1,113 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	auth "github.com/gbloisi-openaire/airflow-client-go/auth"
+)
+
+const (
+	// authModeSimpleJWT exchanges username and password for a JWT with the
+	// simple auth manager of Airflow 3.
+	authModeSimpleJWT = "simple_jwt"
+	// authModeFabJWT exchanges username and password for a JWT at the token
+	// endpoint of the FAB auth manager of Airflow 3.
+	authModeFabJWT = "fab_jwt"
+	// authModeBasic sends username and password as HTTP basic auth, as
+	// expected by the basic_auth API backend of Airflow 2.
+	authModeBasic = "basic"
+	// authModeBearer sends the token given with oauth2_token or obtained
+	// through the OAuth2 client credentials flow.
+	authModeBearer = "bearer"
+)
+
+func authModes() []string {
+	return []string{authModeSimpleJWT, authModeFabJWT, authModeBasic, authModeBearer}
+}
+
+// resolveAuthMode checks mode against the configured credentials, and picks
+// the mode matching them and apiVersion when it is empty.
+func resolveAuthMode(mode string, hasCredentials bool, apiVersion string) (string, error) {
+	if mode == "" {
+		switch {
+		case !hasCredentials:
+			return authModeBearer, nil
+		case apiVersion == apiVersionV2:
+			return authModeSimpleJWT, nil
+		default:
+			return authModeBasic, nil
+		}
+	}
+
+	if mode == authModeBearer {
+		if hasCredentials {
+			return "", fmt.Errorf("auth_mode %s does not use username and password, set oauth2_token or oauth2_client_id instead", mode)
+		}
+		return mode, nil
+	}
+
+	if !hasCredentials {
+		return "", fmt.Errorf("auth_mode %s requires username and password", mode)
+	}
+	if mode != authModeBasic && apiVersion != apiVersionV2 {
+		return "", fmt.Errorf("auth_mode %s requires Airflow 3, use %s with Airflow 2", mode, authModeBasic)
+	}
+
+	return mode, nil
+}
+
+// simpleJWTLogin returns a login function for loginTokenSource obtaining a
+// JWT from the simple auth manager.
+func simpleJWTLogin(httpClient *http.Client, endpoint, username, password string) func() (string, error) {
+	configuration := &auth.Configuration{
+		Debug:      true,
+		HTTPClient: httpClient,
+		Servers: auth.ServerConfigurations{
+			{
+				URL:         strings.TrimSuffix(endpoint, "/"),
+				Description: "Apache Airflow Stable API.",
+			},
+		},
+	}
+	apiClient := auth.NewAPIClient(configuration)
+	loginBody := *auth.NewLoginBody(username, password)
+
+	return func() (string, error) {
+		resp, _, err := apiClient.SimpleAuthManagerLoginAPI.CreateToken(context.Background()).LoginBody(loginBody).Execute()
+		if err != nil {
+			return "", fmt.Errorf("error when calling `SimpleAuthManagerLoginAPI.CreateToken`: %w", err)
+		}
+
+		return resp.AccessToken, nil
+	}
+}
+
+// fabJWTLogin returns a login function for loginTokenSource obtaining a JWT
+// from the token endpoint of the FAB auth manager.
+func fabJWTLogin(httpClient *http.Client, endpoint, username, password string) func() (string, error) {
+	client := &restClient{
+		httpClient: httpClient,
+		basePath:   strings.TrimSuffix(endpoint, "/") + "/auth",
+	}
+	body := map[string]string{
+		"username": username,
+		"password": password,
+	}
+
+	return func() (string, error) {
+		token := struct {
+			AccessToken string `json:"access_token"`
+		}{}
+		if _, err := client.do(context.Background(), http.MethodPost, "/token", nil, body, &token); err != nil {
+			return "", fmt.Errorf("failed to get a token from the FAB auth manager: %w", err)
+		}
+		if token.AccessToken == "" {
+			return "", fmt.Errorf("the FAB auth manager returned no access token")
+		}
+
+		return token.AccessToken, nil
+	}
+}
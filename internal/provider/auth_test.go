@@ -0,0 +1,135 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gbloisi-openaire/airflow-client-go/airflow"
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"golang.org/x/oauth2"
+)
+
+func TestResolveAuthMode(t *testing.T) {
+	cases := []struct {
+		mode           string
+		hasCredentials bool
+		apiVersion     string
+		expected       string
+		expectErr      bool
+	}{
+		{"", false, apiVersionV2, authModeBearer, false},
+		{"", true, apiVersionV2, authModeSimpleJWT, false},
+		{"", true, apiVersionV1, authModeBasic, false},
+		{authModeBasic, true, apiVersionV2, authModeBasic, false},
+		{authModeFabJWT, true, apiVersionV2, authModeFabJWT, false},
+		{authModeFabJWT, true, apiVersionV1, "", true},
+		{authModeSimpleJWT, true, apiVersionV1, "", true},
+		{authModeBasic, false, apiVersionV1, "", true},
+		{authModeBearer, true, apiVersionV2, "", true},
+		{authModeBearer, false, apiVersionV1, authModeBearer, false},
+	}
+
+	for _, c := range cases {
+		got, err := resolveAuthMode(c.mode, c.hasCredentials, c.apiVersion)
+		if (err != nil) != c.expectErr {
+			t.Errorf("resolveAuthMode(%q, %t, %q): unexpected error %v", c.mode, c.hasCredentials, c.apiVersion, err)
+			continue
+		}
+		if got != c.expected {
+			t.Errorf("resolveAuthMode(%q, %t, %q): expected %q, got %q", c.mode, c.hasCredentials, c.apiVersion, c.expected, got)
+		}
+	}
+}
+
+func TestProviderConfigure_basicAuth(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if username, password, _ := r.BasicAuth(); username != "admin" || password != "secret" {
+			t.Errorf("expected basic auth admin/secret, got %s/%s", username, password)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"key":"example","value":"value"}`))
+	}))
+	defer server.Close()
+
+	d := schema.TestResourceDataRaw(t, AirflowProvider("test").Schema, map[string]interface{}{
+		"base_endpoint": server.URL,
+		"username":      "admin",
+		"password":      "secret",
+		"api_version":   "v1",
+	})
+	meta, diags := providerConfigure(context.Background(), d, "")
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	pcfg := meta.(ProviderConfig)
+
+	if _, ok := pcfg.AuthContext.Value(airflow.ContextOAuth2).(oauth2.TokenSource); ok {
+		t.Fatal("expected no token source with basic auth")
+	}
+
+	// Requests are logged, but without the credentials.
+	var logs bytes.Buffer
+	logCtx := tflogtest.RootLogger(pcfg.AuthContext, &logs)
+	if _, found, err := pcfg.API.LookupVariable(logCtx, "example"); err != nil || !found {
+		t.Fatalf("expected the variable to be found, got %t %v", found, err)
+	}
+	if !strings.Contains(logs.String(), "/api/v1/variables/example") {
+		t.Fatalf("expected the request to be logged, got %s", logs.String())
+	}
+	if strings.Contains(logs.String(), "Basic ") {
+		t.Fatalf("expected the credentials not to be logged, got %s", logs.String())
+	}
+}
+
+func TestProviderConfigure_fabJWT(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/auth/token" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+
+		credentials := map[string]string{}
+		if err := json.NewDecoder(r.Body).Decode(&credentials); err != nil {
+			t.Errorf("failed to decode login request: %s", err)
+		}
+		if credentials["username"] != "admin" || credentials["password"] != "secret" {
+			t.Errorf("expected credentials admin/secret, got %v", credentials)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"access_token":"fab-token"}`))
+	}))
+	defer server.Close()
+
+	d := schema.TestResourceDataRaw(t, AirflowProvider("test").Schema, map[string]interface{}{
+		"base_endpoint": server.URL,
+		"username":      "admin",
+		"password":      "secret",
+		"auth_mode":     "fab_jwt",
+		"api_version":   "v2",
+	})
+	meta, diags := providerConfigure(context.Background(), d, "")
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	ts, ok := meta.(ProviderConfig).AuthContext.Value(airflow.ContextOAuth2).(oauth2.TokenSource)
+	if !ok {
+		t.Fatal("expected a token source in the auth context")
+	}
+
+	token, err := ts.Token()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if token.AccessToken != "fab-token" {
+		t.Fatalf("expected the token from the FAB token endpoint, got %q", token.AccessToken)
+	}
+}
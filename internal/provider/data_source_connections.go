@@ -0,0 +1,107 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceConnections() *schema.Resource {
+	return &schema.Resource{
+		ReadWithoutTimeout: dataSourceConnectionsRead,
+		Schema: map[string]*schema.Schema{
+			"connection_id_pattern": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "SQL LIKE expression matched against the connection IDs",
+			},
+			"conn_type": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return connections of this type",
+			},
+			"connection_ids": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"connections": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"connection_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"conn_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"host": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceConnectionsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	pcfg := m.(ProviderConfig)
+	client := pcfg.ApiClient
+
+	pattern := d.Get("connection_id_pattern").(string)
+	connType := d.Get("conn_type").(string)
+
+	connectionIds := make([]interface{}, 0)
+	connections := make([]interface{}, 0)
+
+	for offset := int32(0); ; {
+		req := client.ConnectionAPI.GetConnections(pcfg.AuthContext).Limit(listPageSize).Offset(offset).OrderBy("connection_id")
+		if pattern != "" {
+			req = req.ConnectionIdPattern(pattern)
+		}
+
+		page, _, err := req.Execute()
+		if err != nil {
+			return diag.Errorf("failed to list connections from Airflow: %s", err)
+		}
+
+		for _, connection := range page.Connections {
+			// The API cannot filter on the type.
+			if connType != "" && connection.ConnType != connType {
+				continue
+			}
+
+			connectionIds = append(connectionIds, connection.ConnectionId)
+			connections = append(connections, map[string]interface{}{
+				"connection_id": connection.ConnectionId,
+				"conn_type":     connection.ConnType,
+				"host":          connection.GetHost(),
+				"description":   connection.GetDescription(),
+			})
+		}
+
+		offset += int32(len(page.Connections))
+		if len(page.Connections) == 0 || offset >= page.TotalEntries {
+			break
+		}
+	}
+
+	d.SetId(fmt.Sprintf("%s:%s", pattern, connType))
+	d.Set("connection_ids", connectionIds)
+	if err := d.Set("connections", connections); err != nil {
+		return diag.Errorf("failed to set connections: %s", err)
+	}
+
+	return nil
+}
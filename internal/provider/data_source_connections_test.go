@@ -0,0 +1,53 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccAirflowConnectionsDataSource_connType(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+
+	dataSourceName := "data.airflow_connections.test"
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAirflowConnectionsDataSourceConfigConnType(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "connections.#", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "connection_ids.0", rName+"_pg"),
+					resource.TestCheckResourceAttr(dataSourceName, "connections.0.conn_type", "postgres"),
+					resource.TestCheckResourceAttr(dataSourceName, "connections.0.host", "db.example.com"),
+				),
+			},
+		},
+	})
+}
+
+func testAccAirflowConnectionsDataSourceConfigConnType(rName string) string {
+	return fmt.Sprintf(`
+resource "airflow_connection" "pg" {
+  connection_id = "%[1]s_pg"
+  conn_type     = "postgres"
+  host          = "db.example.com"
+}
+
+resource "airflow_connection" "http" {
+  connection_id = "%[1]s_http"
+  conn_type     = "http"
+  host          = "api.example.com"
+}
+
+data "airflow_connections" "test" {
+  connection_id_pattern = "%[1]s%%"
+  conn_type             = "postgres"
+
+  depends_on = [airflow_connection.pg, airflow_connection.http]
+}
+`, rName)
+}
@@ -0,0 +1,148 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceDag() *schema.Resource {
+	return &schema.Resource{
+		ReadWithoutTimeout: dataSourceDagRead,
+		Schema: map[string]*schema.Schema{
+			"dag_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"dag_display_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"fileloc": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"is_paused": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"is_stale": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"owners": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"tags": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"timetable_summary": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"timetable_description": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"max_active_runs": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"max_active_tasks": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"next_dagrun": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"next_dagrun_run_after": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"last_parsed_time": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"params": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"bundle_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"bundle_version": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceDagRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	pcfg := m.(ProviderConfig)
+	client := pcfg.ApiClient
+
+	dagId := d.Get("dag_id").(string)
+
+	dag, resp, err := client.DAGAPI.GetDagDetails(pcfg.AuthContext, dagId).Execute()
+	if resp != nil && resp.StatusCode == 404 {
+		return diag.Errorf("DAG `%s` not found in Airflow", dagId)
+	}
+	if err != nil {
+		return diag.Errorf("failed to get DAG `%s` from Airflow: %s", dagId, err)
+	}
+
+	tags := make([]interface{}, 0, len(dag.Tags))
+	for _, tag := range dag.Tags {
+		tags = append(tags, tag.Name)
+	}
+
+	params, err := json.Marshal(dag.Params)
+	if err != nil {
+		return diag.Errorf("failed to encode the params of DAG `%s`: %s", dagId, err)
+	}
+
+	d.SetId(dag.DagId)
+	d.Set("dag_display_name", dag.DagDisplayName)
+	d.Set("description", dag.GetDescription())
+	d.Set("fileloc", dag.Fileloc)
+	d.Set("is_paused", dag.IsPaused)
+	d.Set("is_stale", dag.IsStale)
+	d.Set("owners", dag.Owners)
+	d.Set("tags", tags)
+	d.Set("timetable_summary", dag.GetTimetableSummary())
+	d.Set("timetable_description", dag.GetTimetableDescription())
+	d.Set("max_active_runs", dag.GetMaxActiveRuns())
+	d.Set("max_active_tasks", dag.MaxActiveTasks)
+	d.Set("next_dagrun", formatAirflowTime(dag.NextDagrunLogicalDate.Get()))
+	d.Set("next_dagrun_run_after", formatAirflowTime(dag.NextDagrunRunAfter.Get()))
+	d.Set("last_parsed_time", formatAirflowTime(dag.LastParsedTime.Get()))
+	d.Set("params", string(params))
+	d.Set("bundle_name", dag.GetBundleName())
+	d.Set("bundle_version", dag.GetBundleVersion())
+
+	return nil
+}
+
+// formatAirflowTime renders an optional timestamp of the API as RFC 3339,
+// or as an empty string when unset.
+func formatAirflowTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+
+	return t.Format(time.RFC3339)
+}
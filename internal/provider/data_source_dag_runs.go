@@ -0,0 +1,246 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gbloisi-openaire/airflow-client-go/airflow"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// dagRunDateFilters are the date range arguments of the airflow_dag_runs data
+// source, each one maps to the query parameter of the same name.
+var dagRunDateFilters = []string{
+	"logical_date_gte", "logical_date_lte",
+	"start_date_gte", "start_date_lte",
+	"end_date_gte", "end_date_lte",
+}
+
+func dataSourceDagRuns() *schema.Resource {
+	s := map[string]*schema.Schema{
+		"dag_id": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "ID of the DAG, `~` returns the runs of all DAGs",
+		},
+		"state": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			Description: "Only return runs in any of these states",
+			Elem: &schema.Schema{
+				Type:         schema.TypeString,
+				ValidateFunc: validation.StringInSlice(dagRunStates(), false),
+			},
+		},
+		"run_type": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			Description: "Only return runs of any of these types",
+			Elem: &schema.Schema{
+				Type:         schema.TypeString,
+				ValidateFunc: validation.StringInSlice(dagRunTypes(), false),
+			},
+		},
+		"order_by": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Attribute to order the runs by, prefixed with `-` for descending order",
+		},
+		"limit": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Description:  "Maximum number of runs to return, 0 returns all of them",
+			ValidateFunc: validation.IntAtLeast(0),
+		},
+		"dag_run_ids": {
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		},
+		"dag_runs": {
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"dag_id": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"dag_run_id": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"state": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"run_type": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"logical_date": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"run_after": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"start_date": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"end_date": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"duration": {
+						Type:     schema.TypeFloat,
+						Computed: true,
+					},
+					"note": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+				},
+			},
+		},
+	}
+
+	for _, k := range dagRunDateFilters {
+		s[k] = &schema.Schema{
+			Type:         schema.TypeString,
+			Optional:     true,
+			Description:  "RFC 3339 timestamp bounding the runs returned, inclusive",
+			ValidateFunc: validation.IsRFC3339Time,
+		}
+	}
+
+	return &schema.Resource{
+		ReadWithoutTimeout: dataSourceDagRunsRead,
+		Schema:             s,
+	}
+}
+
+func dataSourceDagRunsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	pcfg := m.(ProviderConfig)
+	client := pcfg.ApiClient
+
+	dagId := d.Get("dag_id").(string)
+	states := expandStringPointers(d.Get("state").([]interface{}))
+	runTypes := expandStringPointers(d.Get("run_type").([]interface{}))
+	orderBy := d.Get("order_by").(string)
+	limit := d.Get("limit").(int)
+
+	dates := map[string]time.Time{}
+	for _, k := range dagRunDateFilters {
+		if v, ok := d.GetOk(k); ok {
+			t, err := time.Parse(time.RFC3339, v.(string))
+			if err != nil {
+				return diag.Errorf("invalid %s: %s", k, err)
+			}
+			dates[k] = t
+		}
+	}
+
+	dagRunIds := make([]interface{}, 0)
+	dagRuns := make([]interface{}, 0)
+
+	for offset := int32(0); limit == 0 || len(dagRuns) < limit; {
+		pageSize := int32(listPageSize)
+		if limit > 0 && limit-len(dagRuns) < listPageSize {
+			pageSize = int32(limit - len(dagRuns))
+		}
+
+		req := client.DagRunAPI.GetDagRuns(pcfg.AuthContext, dagId).Limit(pageSize).Offset(offset)
+		if len(states) > 0 {
+			req = req.State(states)
+		}
+		if len(runTypes) > 0 {
+			req = req.RunType(runTypes)
+		}
+		if t, ok := dates["logical_date_gte"]; ok {
+			req = req.LogicalDateGte(t)
+		}
+		if t, ok := dates["logical_date_lte"]; ok {
+			req = req.LogicalDateLte(t)
+		}
+		if t, ok := dates["start_date_gte"]; ok {
+			req = req.StartDateGte(t)
+		}
+		if t, ok := dates["start_date_lte"]; ok {
+			req = req.StartDateLte(t)
+		}
+		if t, ok := dates["end_date_gte"]; ok {
+			req = req.EndDateGte(t)
+		}
+		if t, ok := dates["end_date_lte"]; ok {
+			req = req.EndDateLte(t)
+		}
+		if orderBy != "" {
+			req = req.OrderBy(orderBy)
+		}
+
+		page, resp, err := req.Execute()
+		if resp != nil && resp.StatusCode == 404 {
+			return diag.Errorf("DAG `%s` not found in Airflow", dagId)
+		}
+		if err != nil {
+			return diag.Errorf("failed to list the runs of DAG `%s` from Airflow: %s", dagId, err)
+		}
+
+		for _, dagRun := range page.DagRuns {
+			dagRunIds = append(dagRunIds, dagRun.DagRunId)
+			dagRuns = append(dagRuns, map[string]interface{}{
+				"dag_id":       dagRun.DagId,
+				"dag_run_id":   dagRun.DagRunId,
+				"state":        string(dagRun.State),
+				"run_type":     string(dagRun.RunType),
+				"logical_date": formatAirflowTime(dagRun.LogicalDate.Get()),
+				"run_after":    formatAirflowTime(&dagRun.RunAfter),
+				"start_date":   formatAirflowTime(dagRun.StartDate.Get()),
+				"end_date":     formatAirflowTime(dagRun.EndDate.Get()),
+				"duration":     dagRun.GetDuration(),
+				"note":         dagRun.GetNote(),
+			})
+		}
+
+		offset += int32(len(page.DagRuns))
+		if len(page.DagRuns) == 0 || offset >= page.TotalEntries {
+			break
+		}
+	}
+
+	id := fmt.Sprintf("%s:%v:%v", dagId, d.Get("state"), d.Get("run_type"))
+	for _, k := range dagRunDateFilters {
+		id += ":" + d.Get(k).(string)
+	}
+	d.SetId(fmt.Sprintf("%s:%s:%d", id, orderBy, limit))
+	d.Set("dag_run_ids", dagRunIds)
+	if err := d.Set("dag_runs", dagRuns); err != nil {
+		return diag.Errorf("failed to set DAG runs: %s", err)
+	}
+
+	return nil
+}
+
+func dagRunStates() []string {
+	states := make([]string, 0, len(airflow.AllowedDagRunStateEnumValues))
+	for _, v := range airflow.AllowedDagRunStateEnumValues {
+		states = append(states, string(v))
+	}
+
+	return states
+}
+
+func dagRunTypes() []string {
+	types := make([]string, 0, len(airflow.AllowedDagRunTypeEnumValues))
+	for _, v := range airflow.AllowedDagRunTypeEnumValues {
+		types = append(types, string(v))
+	}
+
+	return types
+}
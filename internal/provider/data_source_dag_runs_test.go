@@ -0,0 +1,56 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccAirflowDagRunsDataSource_basic(t *testing.T) {
+	dagId := "example_bash_operator"
+	dagRunId := acctest.RandomWithPrefix("tf-acc-test")
+
+	dataSourceName := "data.airflow_dag_runs.test"
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAirflowDagRunsDataSourceConfigBasic(dagId, dagRunId),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "dag_runs.#", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "dag_run_ids.0", dagRunId),
+					resource.TestCheckResourceAttr(dataSourceName, "dag_runs.0.dag_id", dagId),
+					resource.TestCheckResourceAttr(dataSourceName, "dag_runs.0.state", "success"),
+					resource.TestCheckResourceAttr(dataSourceName, "dag_runs.0.run_type", "manual"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "dag_runs.0.end_date"),
+				),
+			},
+		},
+	})
+}
+
+func testAccAirflowDagRunsDataSourceConfigBasic(dagId, dagRunId string) string {
+	return fmt.Sprintf(`
+resource "airflow_dag" "test" {
+  dag_id    = %[1]q
+  is_paused = false
+}
+
+resource "airflow_dag_run" "test" {
+  dag_id     = airflow_dag.test.dag_id
+  dag_run_id = %[2]q
+}
+
+data "airflow_dag_runs" "test" {
+  dag_id         = airflow_dag_run.test.dag_id
+  state          = ["success"]
+  run_type       = ["manual"]
+  start_date_gte = "2020-01-01T00:00:00Z"
+  order_by       = "-start_date"
+  limit          = 1
+}
+`, dagId, dagRunId)
+}
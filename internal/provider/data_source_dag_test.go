@@ -0,0 +1,52 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccAirflowDagDataSource_basic(t *testing.T) {
+	dataSourceName := "data.airflow_dag.test"
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAirflowDagDataSourceConfig("tutorial"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "id", "tutorial"),
+					resource.TestCheckResourceAttr(dataSourceName, "tags.0", "example"),
+					resource.TestCheckResourceAttr(dataSourceName, "owners.0", "airflow"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "fileloc"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "timetable_summary"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "max_active_tasks"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "params"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAirflowDagDataSource_missing(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccAirflowDagDataSourceConfig("tf_acc_test_missing"),
+				ExpectError: regexp.MustCompile(`not found`),
+			},
+		},
+	})
+}
+
+func testAccAirflowDagDataSourceConfig(dagId string) string {
+	return fmt.Sprintf(`
+data "airflow_dag" "test" {
+  dag_id = %[1]q
+}
+`, dagId)
+}
@@ -0,0 +1,209 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceDags() *schema.Resource {
+	return &schema.Resource{
+		ReadWithoutTimeout: dataSourceDagsRead,
+		Schema: map[string]*schema.Schema{
+			"tags": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Only return DAGs carrying any of these tags",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"owners": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Only return DAGs owned by any of these owners",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"dag_id_pattern": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "SQL LIKE expression matched against the DAG IDs",
+			},
+			"paused": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Only return paused DAGs when true, unpaused DAGs when false, all of them when unset",
+			},
+			"only_active": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Exclude the stale DAGs, whose file was not seen by the DAG processor recently",
+			},
+			"order_by": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Attribute to order the DAGs by, prefixed with `-` for descending order",
+			},
+			"limit": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "Maximum number of DAGs to return, 0 returns all of them",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"dag_ids": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"dags": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"dag_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"dag_display_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"fileloc": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"is_paused": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"is_stale": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"owners": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"tags": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"timetable_summary": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"next_dagrun": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceDagsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	pcfg := m.(ProviderConfig)
+	client := pcfg.ApiClient
+
+	tags := expandStringPointers(d.Get("tags").([]interface{}))
+	owners := expandStringPointers(d.Get("owners").([]interface{}))
+	pattern := d.Get("dag_id_pattern").(string)
+	onlyActive := d.Get("only_active").(bool)
+	orderBy := d.Get("order_by").(string)
+	limit := d.Get("limit").(int)
+
+	// An unset paused returns both paused and unpaused DAGs, which GetOk
+	// cannot tell apart from false.
+	paused := ""
+	if v := d.GetRawConfig().GetAttr("paused"); !v.IsNull() {
+		paused = fmt.Sprintf("%t", v.True())
+	}
+
+	dagIds := make([]interface{}, 0)
+	dags := make([]interface{}, 0)
+
+	for offset := int32(0); limit == 0 || len(dags) < limit; {
+		pageSize := int32(listPageSize)
+		if limit > 0 && limit-len(dags) < listPageSize {
+			pageSize = int32(limit - len(dags))
+		}
+
+		req := client.DAGAPI.GetDags(pcfg.AuthContext).Limit(pageSize).Offset(offset).ExcludeStale(onlyActive)
+		if len(tags) > 0 {
+			req = req.Tags(tags)
+		}
+		if len(owners) > 0 {
+			req = req.Owners(owners)
+		}
+		if pattern != "" {
+			req = req.DagIdPattern(pattern)
+		}
+		if paused != "" {
+			req = req.Paused(paused == "true")
+		}
+		if orderBy != "" {
+			req = req.OrderBy(orderBy)
+		}
+
+		page, _, err := req.Execute()
+		if err != nil {
+			return diag.Errorf("failed to list DAGs from Airflow: %s", err)
+		}
+
+		for _, dag := range page.Dags {
+			dagTags := make([]interface{}, 0, len(dag.Tags))
+			for _, tag := range dag.Tags {
+				dagTags = append(dagTags, tag.Name)
+			}
+
+			dagIds = append(dagIds, dag.DagId)
+			dags = append(dags, map[string]interface{}{
+				"dag_id":            dag.DagId,
+				"dag_display_name":  dag.DagDisplayName,
+				"description":       dag.GetDescription(),
+				"fileloc":           dag.Fileloc,
+				"is_paused":         dag.IsPaused,
+				"is_stale":          dag.IsStale,
+				"owners":            dag.Owners,
+				"tags":              dagTags,
+				"timetable_summary": dag.GetTimetableSummary(),
+				"next_dagrun":       formatAirflowTime(dag.NextDagrunLogicalDate.Get()),
+			})
+		}
+
+		offset += int32(len(page.Dags))
+		if len(page.Dags) == 0 || offset >= page.TotalEntries {
+			break
+		}
+	}
+
+	d.SetId(fmt.Sprintf("%v:%v:%s:%s:%t:%s:%d", d.Get("tags"), d.Get("owners"), pattern, paused, onlyActive, orderBy, limit))
+	d.Set("dag_ids", dagIds)
+	if err := d.Set("dags", dags); err != nil {
+		return diag.Errorf("failed to set DAGs: %s", err)
+	}
+
+	return nil
+}
+
+// expandStringPointers converts a list attribute to the form the generated
+// client expects for repeated query parameters.
+func expandStringPointers(values []interface{}) []*string {
+	result := make([]*string, 0, len(values))
+	for _, v := range values {
+		s := v.(string)
+		result = append(result, &s)
+	}
+
+	return result
+}
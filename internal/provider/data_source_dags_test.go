@@ -0,0 +1,44 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccAirflowDagsDataSource_tags(t *testing.T) {
+	dataSourceName := "data.airflow_dags.test"
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAirflowDagsDataSourceConfigTags(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "dags.#", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "dag_ids.0", "tutorial"),
+					resource.TestCheckResourceAttr(dataSourceName, "dags.0.tags.0", "example"),
+					resource.TestCheckResourceAttr(dataSourceName, "dags.0.is_paused", "true"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "dags.0.fileloc"),
+				),
+			},
+		},
+	})
+}
+
+func testAccAirflowDagsDataSourceConfigTags() string {
+	return `
+resource "airflow_dag" "test" {
+  dag_id    = "tutorial"
+  is_paused = true
+}
+
+data "airflow_dags" "test" {
+  tags           = ["example"]
+  dag_id_pattern = "tutorial"
+  paused         = true
+
+  depends_on = [airflow_dag.test]
+}
+`
+}
@@ -0,0 +1,67 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceHealth() *schema.Resource {
+	return &schema.Resource{
+		ReadWithoutTimeout: dataSourceHealthRead,
+		Schema: map[string]*schema.Schema{
+			"metadatabase_status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"scheduler_status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"scheduler_latest_heartbeat": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"triggerer_status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"triggerer_latest_heartbeat": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"dag_processor_status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"dag_processor_latest_heartbeat": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceHealthRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	pcfg := m.(ProviderConfig)
+
+	health, _, err := pcfg.API.GetHealth(pcfg.AuthContext)
+	if err != nil {
+		return diag.Errorf("failed to get the health of Airflow: %s", err)
+	}
+
+	d.SetId("health")
+	d.Set("metadatabase_status", health.Metadatabase.GetStatus())
+	d.Set("scheduler_status", health.Scheduler.GetStatus())
+	d.Set("scheduler_latest_heartbeat", health.Scheduler.GetLatestSchedulerHeartbeat())
+	d.Set("triggerer_status", health.Triggerer.GetStatus())
+	d.Set("triggerer_latest_heartbeat", health.Triggerer.GetLatestTriggererHeartbeat())
+
+	// The DAG processor only reports its health when running standalone.
+	dagProcessor := health.GetDagProcessor()
+	d.Set("dag_processor_status", dagProcessor.GetStatus())
+	d.Set("dag_processor_latest_heartbeat", dagProcessor.GetLatestDagProcessorHeartbeat())
+
+	return nil
+}
@@ -0,0 +1,25 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccAirflowHealthDataSource_basic(t *testing.T) {
+	dataSourceName := "data.airflow_health.test"
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `data "airflow_health" "test" {}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "metadatabase_status", "healthy"),
+					resource.TestCheckResourceAttr(dataSourceName, "scheduler_status", "healthy"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "scheduler_latest_heartbeat"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,118 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceImportErrors() *schema.Resource {
+	return &schema.Resource{
+		ReadWithoutTimeout: dataSourceImportErrorsRead,
+		Schema: map[string]*schema.Schema{
+			"filename_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Regular expression matched against the file names",
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+			"bundle_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return the import errors of this DAG bundle",
+			},
+			"filenames": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"import_errors": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"import_error_id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"filename": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"bundle_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"stack_trace": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"timestamp": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceImportErrorsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	pcfg := m.(ProviderConfig)
+	client := pcfg.ApiClient
+
+	filenameRegex := d.Get("filename_regex").(string)
+	bundleName := d.Get("bundle_name").(string)
+
+	filenameRe, err := regexp.Compile(filenameRegex)
+	if err != nil {
+		return diag.Errorf("invalid filename_regex: %s", err)
+	}
+
+	filenames := make([]interface{}, 0)
+	importErrors := make([]interface{}, 0)
+
+	for offset := int32(0); ; {
+		page, _, err := client.ImportErrorAPI.GetImportErrors(pcfg.AuthContext).Limit(listPageSize).Offset(offset).OrderBy("filename").Execute()
+		if err != nil {
+			return diag.Errorf("failed to list import errors from Airflow: %s", err)
+		}
+
+		for _, importError := range page.ImportErrors {
+			// The API cannot filter the import errors.
+			if !filenameRe.MatchString(importError.Filename) {
+				continue
+			}
+			if bundleName != "" && importError.GetBundleName() != bundleName {
+				continue
+			}
+
+			filenames = append(filenames, importError.Filename)
+			importErrors = append(importErrors, map[string]interface{}{
+				"import_error_id": importError.ImportErrorId,
+				"filename":        importError.Filename,
+				"bundle_name":     importError.GetBundleName(),
+				"stack_trace":     importError.StackTrace,
+				"timestamp":       formatAirflowTime(&importError.Timestamp),
+			})
+		}
+
+		offset += int32(len(page.ImportErrors))
+		if len(page.ImportErrors) == 0 || offset >= page.TotalEntries {
+			break
+		}
+	}
+
+	d.SetId(fmt.Sprintf("%s:%s", filenameRegex, bundleName))
+	d.Set("filenames", filenames)
+	if err := d.Set("import_errors", importErrors); err != nil {
+		return diag.Errorf("failed to set import errors: %s", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,85 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestAccAirflowImportErrorsDataSource_basic(t *testing.T) {
+	dataSourceName := "data.airflow_import_errors.test"
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAirflowImportErrorsDataSourceConfigBasic(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "import_errors.#", "0"),
+					resource.TestCheckResourceAttr(dataSourceName, "filenames.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSourceImportErrorsRead_filters(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v2/importErrors" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"import_errors": []map[string]interface{}{
+				{"import_error_id": 1, "timestamp": "2025-01-01T00:00:00Z", "filename": "team_a/dag.py", "bundle_name": "dags-folder", "stack_trace": "SyntaxError"},
+				{"import_error_id": 2, "timestamp": "2025-01-01T00:00:00Z", "filename": "team_b/dag.py", "bundle_name": "dags-folder", "stack_trace": "ImportError"},
+				{"import_error_id": 3, "timestamp": "2025-01-01T00:00:00Z", "filename": "team_a/other.py", "bundle_name": "other", "stack_trace": "NameError"},
+			},
+			"total_entries": 3,
+		})
+	}))
+	defer server.Close()
+
+	p := schema.TestResourceDataRaw(t, AirflowProvider("test").Schema, map[string]interface{}{
+		"base_endpoint": server.URL,
+		"oauth2_token":  "token",
+		"api_version":   "v2",
+	})
+	meta, diags := providerConfigure(context.Background(), p, "")
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	d := schema.TestResourceDataRaw(t, dataSourceImportErrors().Schema, map[string]interface{}{
+		"filename_regex": "^team_a/",
+		"bundle_name":    "dags-folder",
+	})
+	if diags := dataSourceImportErrorsRead(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if got := d.Get("filenames").([]interface{}); len(got) != 1 || got[0] != "team_a/dag.py" {
+		t.Fatalf("expected only team_a/dag.py, got %v", got)
+	}
+	if got := d.Get("import_errors.0.stack_trace"); got != "SyntaxError" {
+		t.Fatalf("expected the stack trace of team_a/dag.py, got %v", got)
+	}
+	if got := d.Get("import_errors.0.timestamp"); got != "2025-01-01T00:00:00Z" {
+		t.Fatalf("unexpected timestamp %v", got)
+	}
+}
+
+func testAccAirflowImportErrorsDataSourceConfigBasic() string {
+	return `
+data "airflow_import_errors" "test" {
+  filename_regex = "^/tf-acc-test/"
+}
+`
+}
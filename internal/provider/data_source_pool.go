@@ -0,0 +1,69 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourcePool() *schema.Resource {
+	return &schema.Resource{
+		ReadWithoutTimeout: dataSourcePoolRead,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"slots": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"include_deferred": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"occupied_slots": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"used_slots": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"queued_slots": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"open_slots": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourcePoolRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	pcfg := m.(ProviderConfig)
+
+	name := d.Get("name").(string)
+
+	pool, found, err := pcfg.API.GetPool(pcfg.AuthContext, name)
+	if err != nil {
+		return diag.Errorf("failed to get pool `%s` from Airflow: %s", name, err)
+	}
+	if !found {
+		return diag.Errorf("pool `%s` not found in Airflow", name)
+	}
+
+	d.SetId(pool.Name)
+	for k, v := range flattenAirflowPool(pool) {
+		d.Set(k, v)
+	}
+
+	return nil
+}
@@ -0,0 +1,69 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccAirflowPoolDataSource_basic(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+
+	dataSourceName := "data.airflow_pool.test"
+	resourceName := "airflow_pool.test"
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAirflowPoolDataSourceConfigBasic(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(dataSourceName, "name", resourceName, "name"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "slots", resourceName, "slots"),
+					resource.TestCheckResourceAttr(dataSourceName, "open_slots", "5"),
+					resource.TestCheckResourceAttr(dataSourceName, "occupied_slots", "0"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "include_deferred"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAirflowPoolDataSource_missing(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccAirflowPoolDataSourceConfigMissing(rName),
+				ExpectError: regexp.MustCompile(`not found`),
+			},
+		},
+	})
+}
+
+func testAccAirflowPoolDataSourceConfigBasic(rName string) string {
+	return fmt.Sprintf(`
+resource "airflow_pool" "test" {
+  name  = %[1]q
+  slots = 5
+}
+
+data "airflow_pool" "test" {
+  name = airflow_pool.test.name
+}
+`, rName)
+}
+
+func testAccAirflowPoolDataSourceConfigMissing(rName string) string {
+	return fmt.Sprintf(`
+data "airflow_pool" "test" {
+  name = %[1]q
+}
+`, rName)
+}
@@ -0,0 +1,144 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gbloisi-openaire/airflow-client-go/airflow"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourcePools() *schema.Resource {
+	return &schema.Resource{
+		ReadWithoutTimeout: dataSourcePoolsRead,
+		Schema: map[string]*schema.Schema{
+			"pool_name_pattern": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "SQL LIKE expression matched against the pool names",
+			},
+			"order_by": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Attribute to order the pools by, prefixed with `-` for descending order",
+			},
+			"limit": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "Maximum number of pools to return, 0 returns all of them",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"names": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"pools": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"slots": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"include_deferred": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"occupied_slots": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"used_slots": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"queued_slots": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"open_slots": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourcePoolsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	pcfg := m.(ProviderConfig)
+	client := pcfg.ApiClient
+
+	pattern := d.Get("pool_name_pattern").(string)
+	orderBy := d.Get("order_by").(string)
+	limit := d.Get("limit").(int)
+
+	names := make([]interface{}, 0)
+	pools := make([]interface{}, 0)
+
+	for offset := int32(0); limit == 0 || len(pools) < limit; {
+		pageSize := int32(listPageSize)
+		if limit > 0 && limit-len(pools) < listPageSize {
+			pageSize = int32(limit - len(pools))
+		}
+
+		req := client.PoolAPI.GetPools(pcfg.AuthContext).Limit(pageSize).Offset(offset)
+		if pattern != "" {
+			req = req.PoolNamePattern(pattern)
+		}
+		if orderBy != "" {
+			req = req.OrderBy(orderBy)
+		}
+
+		page, _, err := req.Execute()
+		if err != nil {
+			return diag.Errorf("failed to list pools from Airflow: %s", err)
+		}
+
+		for i := range page.Pools {
+			names = append(names, page.Pools[i].Name)
+			pools = append(pools, flattenAirflowPool(&page.Pools[i]))
+		}
+
+		offset += int32(len(page.Pools))
+		if len(page.Pools) == 0 || offset >= page.TotalEntries {
+			break
+		}
+	}
+
+	d.SetId(fmt.Sprintf("%s:%s:%d", pattern, orderBy, limit))
+	d.Set("names", names)
+	if err := d.Set("pools", pools); err != nil {
+		return diag.Errorf("failed to set pools: %s", err)
+	}
+
+	return nil
+}
+
+// flattenAirflowPool maps a pool to the attributes of the pool data sources.
+func flattenAirflowPool(pool *airflow.PoolResponse) map[string]interface{} {
+	return map[string]interface{}{
+		"name":             pool.Name,
+		"slots":            pool.Slots,
+		"description":      pool.GetDescription(),
+		"include_deferred": pool.IncludeDeferred,
+		"occupied_slots":   pool.OccupiedSlots,
+		"used_slots":       pool.RunningSlots,
+		"queued_slots":     pool.QueuedSlots,
+		"open_slots":       pool.OpenSlots,
+	}
+}
@@ -0,0 +1,52 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccAirflowPoolsDataSource_pattern(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+
+	dataSourceName := "data.airflow_pools.test"
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAirflowPoolsDataSourceConfigPattern(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "pools.#", "2"),
+					resource.TestCheckResourceAttr(dataSourceName, "names.0", rName+"_a"),
+					resource.TestCheckResourceAttr(dataSourceName, "pools.0.slots", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "pools.1.name", rName+"_b"),
+					resource.TestCheckResourceAttr(dataSourceName, "pools.1.open_slots", "2"),
+				),
+			},
+		},
+	})
+}
+
+func testAccAirflowPoolsDataSourceConfigPattern(rName string) string {
+	return fmt.Sprintf(`
+resource "airflow_pool" "a" {
+  name  = "%[1]s_a"
+  slots = 1
+}
+
+resource "airflow_pool" "b" {
+  name  = "%[1]s_b"
+  slots = 2
+}
+
+data "airflow_pools" "test" {
+  pool_name_pattern = "%[1]s_%%"
+  order_by          = "name"
+
+  depends_on = [airflow_pool.a, airflow_pool.b]
+}
+`, rName)
+}
@@ -0,0 +1,192 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gbloisi-openaire/airflow-client-go/airflow"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceTaskInstances() *schema.Resource {
+	return &schema.Resource{
+		ReadWithoutTimeout: dataSourceTaskInstancesRead,
+		Schema: map[string]*schema.Schema{
+			"dag_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "ID of the DAG, `~` returns the task instances of all DAGs",
+			},
+			"dag_run_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "ID of the DAG run, `~` returns the task instances of all runs",
+			},
+			"task_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return the instances of this task",
+			},
+			"state": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Only return task instances in any of these states",
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice(taskInstanceStates(), false),
+				},
+			},
+			"order_by": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Attribute to order the task instances by, prefixed with `-` for descending order",
+			},
+			"limit": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "Maximum number of task instances to return, 0 returns all of them",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"task_instances": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"dag_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"dag_run_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"task_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"map_index": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"state": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"try_number": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"start_date": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"end_date": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"duration": {
+							Type:     schema.TypeFloat,
+							Computed: true,
+						},
+						"pool": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"queue": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"operator": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceTaskInstancesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	pcfg := m.(ProviderConfig)
+	client := pcfg.ApiClient
+
+	dagId := d.Get("dag_id").(string)
+	dagRunId := d.Get("dag_run_id").(string)
+	taskId := d.Get("task_id").(string)
+	orderBy := d.Get("order_by").(string)
+	limit := d.Get("limit").(int)
+
+	var states []string
+	for _, v := range d.Get("state").([]interface{}) {
+		states = append(states, v.(string))
+	}
+
+	taskInstances := make([]interface{}, 0)
+
+	for offset := int32(0); limit == 0 || len(taskInstances) < limit; {
+		pageSize := int32(listPageSize)
+		if limit > 0 && limit-len(taskInstances) < listPageSize {
+			pageSize = int32(limit - len(taskInstances))
+		}
+
+		req := client.TaskInstanceAPI.GetTaskInstances(pcfg.AuthContext, dagId, dagRunId).Limit(pageSize).Offset(offset)
+		if taskId != "" {
+			req = req.TaskId(taskId)
+		}
+		if len(states) > 0 {
+			req = req.State(states)
+		}
+		if orderBy != "" {
+			req = req.OrderBy(orderBy)
+		}
+
+		page, resp, err := req.Execute()
+		if resp != nil && resp.StatusCode == 404 {
+			return diag.Errorf("DAG run `%s:%s` not found in Airflow", dagId, dagRunId)
+		}
+		if err != nil {
+			return diag.Errorf("failed to list the task instances of `%s:%s` from Airflow: %s", dagId, dagRunId, err)
+		}
+
+		for _, ti := range page.TaskInstances {
+			taskInstances = append(taskInstances, map[string]interface{}{
+				"dag_id":     ti.DagId,
+				"dag_run_id": ti.DagRunId,
+				"task_id":    ti.TaskId,
+				"map_index":  ti.MapIndex,
+				"state":      string(ti.GetState()),
+				"try_number": ti.TryNumber,
+				"start_date": formatAirflowTime(ti.StartDate.Get()),
+				"end_date":   formatAirflowTime(ti.EndDate.Get()),
+				"duration":   ti.GetDuration(),
+				"pool":       ti.Pool,
+				"queue":      ti.GetQueue(),
+				"operator":   ti.GetOperator(),
+			})
+		}
+
+		offset += int32(len(page.TaskInstances))
+		if len(page.TaskInstances) == 0 || offset >= page.TotalEntries {
+			break
+		}
+	}
+
+	d.SetId(fmt.Sprintf("%s:%s:%s:%v:%s:%d", dagId, dagRunId, taskId, states, orderBy, limit))
+	if err := d.Set("task_instances", taskInstances); err != nil {
+		return diag.Errorf("failed to set task instances: %s", err)
+	}
+
+	return nil
+}
+
+func taskInstanceStates() []string {
+	states := make([]string, 0, len(airflow.AllowedTaskInstanceStateEnumValues))
+	for _, v := range airflow.AllowedTaskInstanceStateEnumValues {
+		states = append(states, string(v))
+	}
+
+	return states
+}
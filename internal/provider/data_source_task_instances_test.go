@@ -0,0 +1,54 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccAirflowTaskInstancesDataSource_basic(t *testing.T) {
+	dagId := "example_bash_operator"
+	dagRunId := acctest.RandomWithPrefix("tf-acc-test")
+
+	dataSourceName := "data.airflow_task_instances.test"
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAirflowTaskInstancesDataSourceConfigBasic(dagId, dagRunId),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "task_instances.#", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "task_instances.0.task_id", "runme_0"),
+					resource.TestCheckResourceAttr(dataSourceName, "task_instances.0.dag_run_id", dagRunId),
+					resource.TestCheckResourceAttr(dataSourceName, "task_instances.0.map_index", "-1"),
+					resource.TestCheckResourceAttr(dataSourceName, "task_instances.0.state", "success"),
+					resource.TestCheckResourceAttr(dataSourceName, "task_instances.0.pool", "default_pool"),
+					resource.TestCheckResourceAttr(dataSourceName, "task_instances.0.operator", "BashOperator"),
+				),
+			},
+		},
+	})
+}
+
+func testAccAirflowTaskInstancesDataSourceConfigBasic(dagId, dagRunId string) string {
+	return fmt.Sprintf(`
+resource "airflow_dag" "test" {
+  dag_id    = %[1]q
+  is_paused = false
+}
+
+resource "airflow_dag_run" "test" {
+  dag_id     = airflow_dag.test.dag_id
+  dag_run_id = %[2]q
+}
+
+data "airflow_task_instances" "test" {
+  dag_id     = airflow_dag_run.test.dag_id
+  dag_run_id = airflow_dag_run.test.dag_run_id
+  task_id    = "runme_0"
+}
+`, dagId, dagRunId)
+}
@@ -0,0 +1,54 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceVariable() *schema.Resource {
+	return &schema.Resource{
+		ReadWithoutTimeout: dataSourceVariableRead,
+		Schema: map[string]*schema.Schema{
+			"key": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"value": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"is_encrypted": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceVariableRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	pcfg := m.(ProviderConfig)
+
+	key := d.Get("key").(string)
+
+	variable, found, err := pcfg.API.GetVariable(pcfg.AuthContext, key)
+	if err != nil {
+		return diag.Errorf("failed to get variable `%s` from Airflow: %s", key, err)
+	}
+	if !found {
+		return diag.Errorf("variable `%s` not found in Airflow", key)
+	}
+
+	d.SetId(variable.Key)
+	d.Set("value", variable.Value)
+	d.Set("description", variable.GetDescription())
+	d.Set("is_encrypted", variable.IsEncrypted)
+
+	return nil
+}
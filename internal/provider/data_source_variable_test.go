@@ -0,0 +1,69 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccAirflowVariableDataSource_basic(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+
+	dataSourceName := "data.airflow_variable.test"
+	resourceName := "airflow_variable.test"
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAirflowVariableDataSourceConfigBasic(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(dataSourceName, "key", resourceName, "key"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "value", resourceName, "value"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "description", resourceName, "description"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "is_encrypted"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAirflowVariableDataSource_missing(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccAirflowVariableDataSourceConfigMissing(rName),
+				ExpectError: regexp.MustCompile(`not found`),
+			},
+		},
+	})
+}
+
+func testAccAirflowVariableDataSourceConfigBasic(rName string) string {
+	return fmt.Sprintf(`
+resource "airflow_variable" "test" {
+  key         = %[1]q
+  value       = %[1]q
+  description = "test"
+}
+
+data "airflow_variable" "test" {
+  key = airflow_variable.test.key
+}
+`, rName)
+}
+
+func testAccAirflowVariableDataSourceConfigMissing(rName string) string {
+	return fmt.Sprintf(`
+data "airflow_variable" "test" {
+  key = %[1]q
+}
+`, rName)
+}
@@ -0,0 +1,114 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceVariables() *schema.Resource {
+	return &schema.Resource{
+		ReadWithoutTimeout: dataSourceVariablesRead,
+		Schema: map[string]*schema.Schema{
+			"variable_key_pattern": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "SQL LIKE expression matched against the variable keys, e.g. `team_x_%`",
+			},
+			"order_by": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Attribute to order the variables by, prefixed with `-` for descending order",
+			},
+			"limit": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "Maximum number of variables to return, 0 returns all of them",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"keys": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"variables": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"value": {
+							Type:      schema.TypeString,
+							Computed:  true,
+							Sensitive: true,
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceVariablesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	pcfg := m.(ProviderConfig)
+	client := pcfg.ApiClient
+
+	pattern := d.Get("variable_key_pattern").(string)
+	orderBy := d.Get("order_by").(string)
+	limit := d.Get("limit").(int)
+
+	keys := make([]interface{}, 0)
+	variables := make([]interface{}, 0)
+
+	for offset := int32(0); limit == 0 || len(variables) < limit; {
+		pageSize := int32(listPageSize)
+		if limit > 0 && limit-len(variables) < listPageSize {
+			pageSize = int32(limit - len(variables))
+		}
+
+		req := client.VariableAPI.GetVariables(pcfg.AuthContext).Limit(pageSize).Offset(offset)
+		if pattern != "" {
+			req = req.VariableKeyPattern(pattern)
+		}
+		if orderBy != "" {
+			req = req.OrderBy(orderBy)
+		}
+
+		page, _, err := req.Execute()
+		if err != nil {
+			return diag.Errorf("failed to list variables from Airflow: %s", err)
+		}
+
+		for _, variable := range page.Variables {
+			keys = append(keys, variable.Key)
+			variables = append(variables, map[string]interface{}{
+				"key":         variable.Key,
+				"value":       variable.Value,
+				"description": variable.GetDescription(),
+			})
+		}
+
+		offset += int32(len(page.Variables))
+		if len(page.Variables) == 0 || offset >= page.TotalEntries {
+			break
+		}
+	}
+
+	d.SetId(fmt.Sprintf("%s:%s:%d", pattern, orderBy, limit))
+	d.Set("keys", keys)
+	if err := d.Set("variables", variables); err != nil {
+		return diag.Errorf("failed to set variables: %s", err)
+	}
+
+	return nil
+}
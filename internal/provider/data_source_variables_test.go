@@ -0,0 +1,52 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccAirflowVariablesDataSource_pattern(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+
+	dataSourceName := "data.airflow_variables.test"
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAirflowVariablesDataSourceConfigPattern(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "variables.#", "2"),
+					resource.TestCheckResourceAttr(dataSourceName, "keys.#", "2"),
+					resource.TestCheckResourceAttr(dataSourceName, "keys.0", rName+"_a"),
+					resource.TestCheckResourceAttr(dataSourceName, "variables.0.value", "a"),
+					resource.TestCheckResourceAttr(dataSourceName, "variables.1.key", rName+"_b"),
+				),
+			},
+		},
+	})
+}
+
+func testAccAirflowVariablesDataSourceConfigPattern(rName string) string {
+	return fmt.Sprintf(`
+resource "airflow_variable" "a" {
+  key   = "%[1]s_a"
+  value = "a"
+}
+
+resource "airflow_variable" "b" {
+  key   = "%[1]s_b"
+  value = "b"
+}
+
+data "airflow_variables" "test" {
+  variable_key_pattern = "%[1]s_%%"
+  order_by             = "key"
+
+  depends_on = [airflow_variable.a, airflow_variable.b]
+}
+`, rName)
+}
@@ -0,0 +1,84 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceVersion() *schema.Resource {
+	return &schema.Resource{
+		ReadWithoutTimeout: dataSourceVersionRead,
+		Schema: map[string]*schema.Schema{
+			"version": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"git_version": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"major": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"minor": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"patch": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceVersionRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	pcfg := m.(ProviderConfig)
+
+	// The version is known already when it was detected at configuration.
+	version := pcfg.AirflowVersion
+	if version == nil {
+		var err error
+		version, _, err = pcfg.API.GetVersion(pcfg.AuthContext)
+		if err != nil {
+			return diag.Errorf("failed to get the version of Airflow: %s", err)
+		}
+	}
+
+	major, minor, patch, err := parseAirflowVersion(version.Version)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(version.Version)
+	d.Set("version", version.Version)
+	d.Set("git_version", version.GetGitVersion())
+	d.Set("major", major)
+	d.Set("minor", minor)
+	d.Set("patch", patch)
+
+	return nil
+}
+
+var airflowVersionRegexp = regexp.MustCompile(`^(\d+)\.(\d+)\.(\d+)`)
+
+// parseAirflowVersion extracts the numeric parts of a version such as
+// `3.0.2` or `2.10.0rc1`, ignoring any pre-release or local suffix.
+func parseAirflowVersion(version string) (major, minor, patch int, err error) {
+	parts := airflowVersionRegexp.FindStringSubmatch(version)
+	if parts == nil {
+		return 0, 0, 0, fmt.Errorf("unexpected Airflow version `%s`", version)
+	}
+
+	major, _ = strconv.Atoi(parts[1])
+	minor, _ = strconv.Atoi(parts[2])
+	patch, _ = strconv.Atoi(parts[3])
+
+	return major, minor, patch, nil
+}
@@ -0,0 +1,51 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccAirflowVersionDataSource_basic(t *testing.T) {
+	dataSourceName := "data.airflow_version.test"
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `data "airflow_version" "test" {}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(dataSourceName, "version"),
+					resource.TestCheckResourceAttr(dataSourceName, "major", "3"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "minor"),
+				),
+			},
+		},
+	})
+}
+
+func TestParseAirflowVersion(t *testing.T) {
+	cases := []struct {
+		version             string
+		major, minor, patch int
+	}{
+		{"3.0.2", 3, 0, 2},
+		{"2.10.5", 2, 10, 5},
+		{"3.1.0rc1", 3, 1, 0},
+		{"3.1.0.dev0+astro.1", 3, 1, 0},
+	}
+
+	for _, c := range cases {
+		major, minor, patch, err := parseAirflowVersion(c.version)
+		if err != nil {
+			t.Fatalf("unexpected error for %s: %s", c.version, err)
+		}
+		if major != c.major || minor != c.minor || patch != c.patch {
+			t.Fatalf("expected %s to be %d.%d.%d, got %d.%d.%d", c.version, c.major, c.minor, c.patch, major, minor, patch)
+		}
+	}
+
+	if _, _, _, err := parseAirflowVersion("main"); err == nil {
+		t.Fatal("expected an error for a version without numbers")
+	}
+}
@@ -0,0 +1,247 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// commandTimeout bounds the time a token command may take.
+const commandTimeout = time.Minute
+
+// execCredential is the output expected from a token command, in the format
+// of the exec credential plugins of kubectl.
+type execCredential struct {
+	Status struct {
+		Token               string    `json:"token"`
+		ExpirationTimestamp time.Time `json:"expirationTimestamp"`
+	} `json:"status"`
+}
+
+// commandTokenSource is an oauth2.TokenSource running an external command to
+// obtain a token. Tokens with a known expiry are cached on disk until they
+// are about to expire, so that concurrent resources and the successive
+// Terraform commands of a run share them instead of running the command
+// again.
+type commandTokenSource struct {
+	mu        sync.Mutex
+	command   []string
+	cacheFile string
+	token     *oauth2.Token
+}
+
+// newCommandTokenSource returns a source running command, a program followed
+// by its arguments, for the Airflow at endpoint. Tokens are cached in
+// cacheDir, or only in memory when it is empty. The cache is keyed by the
+// endpoint too, so that the same helper used against several Airflows never
+// shares their tokens.
+func newCommandTokenSource(command []string, endpoint, cacheDir string) *commandTokenSource {
+	s := &commandTokenSource{command: command}
+
+	if cacheDir != "" {
+		key := sha256.Sum256([]byte(strings.Join(append([]string{endpoint}, command...), "\x00")))
+		s.cacheFile = filepath.Join(cacheDir, hex.EncodeToString(key[:])+".json")
+	}
+
+	return s
+}
+
+// tokenCacheDir returns the directory where the tokens of commands are
+// cached, or an empty string when the user has no cache directory.
+func tokenCacheDir() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		log.Printf("[WARN] Unable to find a cache directory, token command results are not cached on disk: %s", err)
+		return ""
+	}
+
+	return filepath.Join(dir, "terraform-provider-airflow", "tokens")
+}
+
+func (s *commandTokenSource) Token() (*oauth2.Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if tokenFresh(s.token) {
+		return s.token, nil
+	}
+
+	if token := s.readCache(); tokenFresh(token) {
+		s.token = token
+		return s.token, nil
+	}
+
+	log.Printf("[DEBUG] Running the token command %s", s.command[0])
+	token, err := s.run()
+	if err != nil {
+		return nil, err
+	}
+	s.writeCache(token)
+	s.token = token
+
+	return s.token, nil
+}
+
+// invalidate drops the token from memory and disk if it is still the
+// rejected one.
+func (s *commandTokenSource) invalidate(rejected string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token != nil && s.token.AccessToken == rejected {
+		s.token = nil
+		if s.cacheFile != "" {
+			os.Remove(s.cacheFile)
+		}
+	}
+}
+
+func (s *commandTokenSource) run() (*oauth2.Token, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), commandTimeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, s.command[0], s.command[1:]...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("token command %s failed: %w: %s", s.command[0], err, strings.TrimSpace(stderr.String()))
+	}
+
+	credential := execCredential{}
+	if err := json.Unmarshal(stdout.Bytes(), &credential); err != nil {
+		return nil, fmt.Errorf("failed to parse the output of token command %s: %w", s.command[0], err)
+	}
+	if credential.Status.Token == "" {
+		return nil, fmt.Errorf("token command %s returned no status.token", s.command[0])
+	}
+
+	expiry := credential.Status.ExpirationTimestamp
+	if expiry.IsZero() {
+		// The token may still tell its expiry when it is a JWT.
+		expiry, _ = jwtExpiry(credential.Status.Token)
+	}
+
+	return &oauth2.Token{
+		AccessToken: credential.Status.Token,
+		TokenType:   "Bearer",
+		Expiry:      expiry,
+	}, nil
+}
+
+func (s *commandTokenSource) readCache() *oauth2.Token {
+	if s.cacheFile == "" {
+		return nil
+	}
+
+	buf, err := os.ReadFile(s.cacheFile)
+	if err != nil {
+		return nil
+	}
+
+	token := &oauth2.Token{}
+	if err := json.Unmarshal(buf, token); err != nil {
+		log.Printf("[WARN] Ignoring the invalid token cache %s: %s", s.cacheFile, err)
+		return nil
+	}
+
+	return token
+}
+
+// writeCache stores token on disk, unless its expiry is unknown. The file is
+// replaced atomically so that concurrent readers never see it half written.
+func (s *commandTokenSource) writeCache(token *oauth2.Token) {
+	if s.cacheFile == "" || token.Expiry.IsZero() {
+		return
+	}
+
+	if err := writeFileAtomic(s.cacheFile, token); err != nil {
+		log.Printf("[WARN] Failed to cache the token in %s: %s", s.cacheFile, err)
+	}
+}
+
+func writeFileAtomic(path string, v interface{}) error {
+	buf, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(dir, filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(buf); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+// fileTokenSource is an oauth2.TokenSource reading the token from a file,
+// read again whenever it is modified, e.g. by a credential helper renewing
+// it in the background.
+type fileTokenSource struct {
+	mu      sync.Mutex
+	path    string
+	modTime time.Time
+	token   *oauth2.Token
+}
+
+func newFileTokenSource(path string) *fileTokenSource {
+	return &fileTokenSource{path: path}
+}
+
+func (s *fileTokenSource) Token() (*oauth2.Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	info, err := os.Stat(s.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read token file: %w", err)
+	}
+	if s.token != nil && info.ModTime().Equal(s.modTime) {
+		return s.token, nil
+	}
+
+	buf, err := os.ReadFile(s.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read token file: %w", err)
+	}
+	accessToken := strings.TrimSpace(string(buf))
+	if accessToken == "" {
+		return nil, fmt.Errorf("token file %s is empty", s.path)
+	}
+
+	log.Printf("[DEBUG] Read the Airflow API token from %s", s.path)
+	s.modTime = info.ModTime()
+	s.token = &oauth2.Token{
+		AccessToken: accessToken,
+		TokenType:   "Bearer",
+	}
+
+	return s.token, nil
+}
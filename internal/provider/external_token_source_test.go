@@ -0,0 +1,120 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testTokenCommand returns a command printing an exec credential whose token
+// is the number of times it ran, counted in a file of dir.
+func testTokenCommand(dir string, expiry time.Time) []string {
+	counter := filepath.Join(dir, "runs")
+	script := fmt.Sprintf(`echo x >> %[1]q; printf '{"status":{"token":"token-%%s","expirationTimestamp":"%[2]s"}}' "$(wc -l < %[1]q | tr -d ' ')"`, counter, expiry.Format(time.RFC3339))
+
+	return []string{"sh", "-c", script}
+}
+
+func TestCommandTokenSource_cachesOnDisk(t *testing.T) {
+	dir := t.TempDir()
+	cacheDir := filepath.Join(dir, "cache")
+	command := testTokenCommand(dir, time.Now().Add(time.Hour))
+
+	token, err := newCommandTokenSource(command, "https://a.example.com", cacheDir).Token()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if token.AccessToken != "token-1" {
+		t.Fatalf("expected the token printed by the command, got %q", token.AccessToken)
+	}
+
+	// Another source, as in another Terraform command, reads the cached token.
+	source := newCommandTokenSource(command, "https://a.example.com", cacheDir)
+	token, err = source.Token()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if token.AccessToken != "token-1" {
+		t.Fatalf("expected the cached token, got %q", token.AccessToken)
+	}
+
+	source.invalidate(token.AccessToken)
+	token, err = source.Token()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if token.AccessToken != "token-2" {
+		t.Fatalf("expected a rejected token to be renewed, got %q", token.AccessToken)
+	}
+
+	// The same command against another Airflow does not share the cache.
+	token, err = newCommandTokenSource(command, "https://b.example.com", cacheDir).Token()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if token.AccessToken != "token-3" {
+		t.Fatalf("expected the command to run for another endpoint, got %q", token.AccessToken)
+	}
+}
+
+func TestCommandTokenSource_renewsExpiringToken(t *testing.T) {
+	dir := t.TempDir()
+	source := newCommandTokenSource(testTokenCommand(dir, time.Now().Add(tokenRefreshMargin/2)), "https://a.example.com", filepath.Join(dir, "cache"))
+
+	first, err := source.Token()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	second, err := source.Token()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if first.AccessToken == second.AccessToken {
+		t.Fatal("expected a token close to its expiry to be renewed")
+	}
+}
+
+func TestCommandTokenSource_commandError(t *testing.T) {
+	source := newCommandTokenSource([]string{"sh", "-c", "echo denied >&2; exit 1"}, "https://a.example.com", "")
+
+	_, err := source.Token()
+	if err == nil || !strings.Contains(err.Error(), "denied") {
+		t.Fatalf("expected the error output of the command, got %v", err)
+	}
+}
+
+func TestFileTokenSource_rereadsWhenModified(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(path, []byte("first\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	source := newFileTokenSource(path)
+
+	token, err := source.Token()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if token.AccessToken != "first" {
+		t.Fatalf("expected the token of the file, got %q", token.AccessToken)
+	}
+
+	if err := os.WriteFile(path, []byte("second\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	modTime := time.Now().Add(time.Minute)
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatal(err)
+	}
+
+	token, err = source.Token()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if token.AccessToken != "second" {
+		t.Fatalf("expected the token to be read again, got %q", token.AccessToken)
+	}
+}
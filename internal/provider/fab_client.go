@@ -0,0 +1,136 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// fabClient talks to the role and user endpoints of the FAB auth manager.
+// They are not part of the Airflow core API, so the generated client does not
+// cover them. Airflow 2 serves them under /api/v1, Airflow 3 under
+// /auth/fab/v1.
+type fabClient struct {
+	*restClient
+}
+
+type fabAction struct {
+	Name string `json:"name"`
+}
+
+type fabResource struct {
+	Name string `json:"name"`
+}
+
+type fabActionResource struct {
+	Action   fabAction   `json:"action"`
+	Resource fabResource `json:"resource"`
+}
+
+type fabRole struct {
+	Name    string              `json:"name"`
+	Actions []fabActionResource `json:"actions"`
+}
+
+type fabUserRole struct {
+	Name string `json:"name"`
+}
+
+type fabUser struct {
+	Username         string        `json:"username"`
+	Email            string        `json:"email"`
+	FirstName        string        `json:"first_name"`
+	LastName         string        `json:"last_name"`
+	Password         string        `json:"password,omitempty"`
+	Roles            []fabUserRole `json:"roles"`
+	Active           bool          `json:"active,omitempty"`
+	FailedLoginCount int           `json:"failed_login_count,omitempty"`
+	LoginCount       int           `json:"login_count,omitempty"`
+}
+
+func newFabClient(httpClient *http.Client, basePath string) *fabClient {
+	return &fabClient{
+		restClient: &restClient{
+			httpClient: httpClient,
+			basePath:   basePath,
+		},
+	}
+}
+
+func (c *fabClient) GetRole(ctx context.Context, name string) (*fabRole, *http.Response, error) {
+	role := &fabRole{}
+	resp, err := c.do(ctx, http.MethodGet, "/roles/"+url.PathEscape(name), nil, nil, role)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return role, resp, nil
+}
+
+func (c *fabClient) PostRole(ctx context.Context, role fabRole) (*fabRole, *http.Response, error) {
+	created := &fabRole{}
+	resp, err := c.do(ctx, http.MethodPost, "/roles", nil, role, created)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return created, resp, nil
+}
+
+func (c *fabClient) PatchRole(ctx context.Context, name string, role fabRole, updateMask []string) (*fabRole, *http.Response, error) {
+	query := url.Values{}
+	for _, field := range updateMask {
+		query.Add("update_mask", field)
+	}
+
+	updated := &fabRole{}
+	resp, err := c.do(ctx, http.MethodPatch, "/roles/"+url.PathEscape(name), query, role, updated)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return updated, resp, nil
+}
+
+func (c *fabClient) DeleteRole(ctx context.Context, name string) (*http.Response, error) {
+	return c.do(ctx, http.MethodDelete, "/roles/"+url.PathEscape(name), nil, nil, nil)
+}
+
+func (c *fabClient) GetUser(ctx context.Context, username string) (*fabUser, *http.Response, error) {
+	user := &fabUser{}
+	resp, err := c.do(ctx, http.MethodGet, "/users/"+url.PathEscape(username), nil, nil, user)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return user, resp, nil
+}
+
+func (c *fabClient) PostUser(ctx context.Context, user fabUser) (*fabUser, *http.Response, error) {
+	created := &fabUser{}
+	resp, err := c.do(ctx, http.MethodPost, "/users", nil, user, created)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return created, resp, nil
+}
+
+func (c *fabClient) PatchUser(ctx context.Context, username string, user fabUser, updateMask []string) (*fabUser, *http.Response, error) {
+	query := url.Values{}
+	for _, field := range updateMask {
+		query.Add("update_mask", field)
+	}
+
+	updated := &fabUser{}
+	resp, err := c.do(ctx, http.MethodPatch, "/users/"+url.PathEscape(username), query, user, updated)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return updated, resp, nil
+}
+
+func (c *fabClient) DeleteUser(ctx context.Context, username string) (*http.Response, error) {
+	return c.do(ctx, http.MethodDelete, "/users/"+url.PathEscape(username), nil, nil, nil)
+}
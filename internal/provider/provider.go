@@ -2,28 +2,31 @@ package provider
 
 import (
 	"context"
-	"crypto/tls"
 	"log"
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/gbloisi-openaire/airflow-client-go/airflow"
-	auth "github.com/gbloisi-openaire/airflow-client-go/auth"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/logging"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
 	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
 )
 
 type ProviderConfig struct {
-	ApiClient   *airflow.APIClient
-	AuthContext context.Context
+	ApiClient      *airflow.APIClient
+	API            airflowAPI
+	APIVersion     string
+	AirflowVersion *airflow.VersionInfo
+	FabClient      *fabClient
+	AuthContext    context.Context
 }
 
-func AirflowProvider() *schema.Provider {
+func AirflowProvider(version string) *schema.Provider {
 	provider := &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"base_endpoint": {
@@ -38,7 +41,53 @@ func AirflowProvider() *schema.Provider {
 				Sensitive:     true,
 				Description:   "The oauth to use for API authentication",
 				DefaultFunc:   schema.EnvDefaultFunc("AIRFLOW_OAUTH2_TOKEN", nil),
-				ConflictsWith: []string{"username", "password"},
+				ConflictsWith: []string{"username", "password", "oauth2_client_id", "token_command", "token_file"},
+			},
+			"oauth2_client_id": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "The client ID to use for the OAuth2 client credentials flow",
+				DefaultFunc:   schema.EnvDefaultFunc("AIRFLOW_OAUTH2_CLIENT_ID", nil),
+				RequiredWith:  []string{"oauth2_client_secret", "oauth2_token_url"},
+				ConflictsWith: []string{"oauth2_token", "username", "password", "token_command", "token_file"},
+			},
+			"oauth2_client_secret": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				Description:  "The client secret to use for the OAuth2 client credentials flow",
+				DefaultFunc:  schema.EnvDefaultFunc("AIRFLOW_OAUTH2_CLIENT_SECRET", nil),
+				RequiredWith: []string{"oauth2_client_id"},
+			},
+			"oauth2_token_url": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "The token endpoint to use for the OAuth2 client credentials flow",
+				DefaultFunc:  schema.EnvDefaultFunc("AIRFLOW_OAUTH2_TOKEN_URL", nil),
+				ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+				RequiredWith: []string{"oauth2_client_id"},
+			},
+			"oauth2_scopes": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				Description:  "The scopes to request in the OAuth2 client credentials flow",
+				Elem:         &schema.Schema{Type: schema.TypeString},
+				RequiredWith: []string{"oauth2_client_id"},
+			},
+			"token_command": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				Description:   "A command printing a token in the JSON format of kubectl exec credentials, followed by its arguments",
+				Elem:          &schema.Schema{Type: schema.TypeString},
+				MinItems:      1,
+				ConflictsWith: []string{"oauth2_token", "oauth2_client_id", "username", "password", "token_file"},
+			},
+			"token_file": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "Path to a file containing the token, read again when modified",
+				DefaultFunc:   schema.EnvDefaultFunc("AIRFLOW_TOKEN_FILE", nil),
+				ConflictsWith: []string{"oauth2_token", "oauth2_client_id", "username", "password", "token_command"},
 			},
 			"username": {
 				Type:          schema.TypeString,
@@ -46,7 +95,7 @@ func AirflowProvider() *schema.Provider {
 				Optional:      true,
 				Description:   "The username to use for API basic authentication",
 				RequiredWith:  []string{"password"},
-				ConflictsWith: []string{"oauth2_token"},
+				ConflictsWith: []string{"oauth2_token", "oauth2_client_id", "token_command", "token_file"},
 			},
 			"password": {
 				Type:          schema.TypeString,
@@ -55,7 +104,7 @@ func AirflowProvider() *schema.Provider {
 				Sensitive:     true,
 				Description:   "The password to use for API basic authentication",
 				RequiredWith:  []string{"username"},
-				ConflictsWith: []string{"oauth2_token"},
+				ConflictsWith: []string{"oauth2_token", "oauth2_client_id", "token_command", "token_file"},
 			},
 			"disable_ssl_verification": {
 				Type:        schema.TypeBool,
@@ -63,6 +112,102 @@ func AirflowProvider() *schema.Provider {
 				Description: "Disable SSL verification",
 				Default:     false,
 			},
+			"ca_cert_pem": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "PEM encoded CA certificates used to verify the Airflow server",
+				ConflictsWith: []string{"ca_cert_file"},
+			},
+			"ca_cert_file": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "Path to a PEM encoded CA bundle used to verify the Airflow server",
+				DefaultFunc:   schema.EnvDefaultFunc("AIRFLOW_CA_CERT_FILE", nil),
+				ConflictsWith: []string{"ca_cert_pem"},
+			},
+			"client_cert_pem": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "PEM encoded client certificate for mutual TLS",
+				ConflictsWith: []string{"client_cert_file"},
+			},
+			"client_key_pem": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				Description:   "PEM encoded private key of the client certificate",
+				ConflictsWith: []string{"client_key_file"},
+			},
+			"client_cert_file": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "Path to a PEM encoded client certificate for mutual TLS",
+				DefaultFunc:   schema.EnvDefaultFunc("AIRFLOW_CLIENT_CERT_FILE", nil),
+				ConflictsWith: []string{"client_cert_pem"},
+			},
+			"client_key_file": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "Path to the PEM encoded private key of the client certificate",
+				DefaultFunc:   schema.EnvDefaultFunc("AIRFLOW_CLIENT_KEY_FILE", nil),
+				ConflictsWith: []string{"client_key_pem"},
+			},
+			"max_retries": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "Maximum number of retries of a request failing with a transient error",
+				Default:      4,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"retry_min_wait": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "Minimum time in seconds to wait before retrying a request",
+				Default:      1,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"retry_max_wait": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "Maximum time in seconds to wait before retrying a request",
+				Default:      30,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"requests_per_second": {
+				Type:         schema.TypeFloat,
+				Optional:     true,
+				Description:  "Maximum number of requests per second sent to the Airflow API, 0 means unlimited",
+				DefaultFunc:  schema.EnvDefaultFunc("AIRFLOW_REQUESTS_PER_SECOND", 0),
+				ValidateFunc: validation.FloatAtLeast(0),
+			},
+			"max_concurrent_requests": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "Maximum number of concurrent requests sent to the Airflow API, 0 means unlimited",
+				DefaultFunc:  schema.EnvDefaultFunc("AIRFLOW_MAX_CONCURRENT_REQUESTS", 0),
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"auth_mode": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "How to authenticate: `simple_jwt`, `fab_jwt`, `basic` or `bearer`, picked from the credentials and the Airflow version when unset",
+				DefaultFunc:  schema.EnvDefaultFunc("AIRFLOW_AUTH_MODE", nil),
+				ValidateFunc: validation.StringInSlice(authModes(), false),
+			},
+			"headers": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "Additional HTTP headers sent with every request to Airflow",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"api_version": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "The Airflow API to use, `v1` for Airflow 2 or `v2` for Airflow 3, detected from the server when unset",
+				DefaultFunc:  schema.EnvDefaultFunc("AIRFLOW_API_VERSION", nil),
+				ValidateFunc: validation.StringInSlice([]string{apiVersionV1, apiVersionV2}, false),
+			},
 		},
 		ResourcesMap: map[string]*schema.Resource{
 			"airflow_connection": resourceConnection(),
@@ -70,28 +215,70 @@ func AirflowProvider() *schema.Provider {
 			"airflow_dag_run":    resourceDagRun(),
 			"airflow_variable":   resourceVariable(),
 			"airflow_pool":       resourcePool(),
+			"airflow_role":       resourceRole(),
+			"airflow_user":       resourceUser(),
+		},
+		DataSourcesMap: map[string]*schema.Resource{
+			"airflow_connection":     dataSourceConnection(),
+			"airflow_connections":    apiV2Only(dataSourceConnections()),
+			"airflow_dag":            apiV2Only(dataSourceDag()),
+			"airflow_dag_runs":       apiV2Only(dataSourceDagRuns()),
+			"airflow_dags":           apiV2Only(dataSourceDags()),
+			"airflow_health":         dataSourceHealth(),
+			"airflow_import_errors":  apiV2Only(dataSourceImportErrors()),
+			"airflow_pool":           dataSourcePool(),
+			"airflow_pools":          apiV2Only(dataSourcePools()),
+			"airflow_task_instances": apiV2Only(dataSourceTaskInstances()),
+			"airflow_variable":       dataSourceVariable(),
+			"airflow_variables":      apiV2Only(dataSourceVariables()),
+			"airflow_version":        dataSourceVersion(),
 		},
 		// ConfigureContextFunc: providerConfigure,
 	}
 
 	provider.ConfigureContextFunc = func(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
-		return providerConfigure(ctx, d)
+		return providerConfigure(ctx, d, userAgent(version, provider.TerraformVersion))
 	}
 
 	return provider
 }
 
-func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
-	var transport http.RoundTripper
+func providerConfigure(ctx context.Context, d *schema.ResourceData, userAgent string) (interface{}, diag.Diagnostics) {
+	tlsConfig, err := providerTLSConfig(d)
+	if err != nil {
+		return nil, diag.Errorf("invalid TLS configuration: %s", err)
+	}
 
-	if disableSSL := d.Get("disable_ssl_verification").(bool); disableSSL {
-		transport = &http.Transport{
-			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
-		}
-	} else {
-		transport = logging.NewLoggingHTTPTransport(http.DefaultTransport)
+	headers := map[string]string{}
+	for k, v := range d.Get("headers").(map[string]interface{}) {
+		headers[k] = v.(string)
 	}
 
+	// newTransport builds the transports to Airflow with authLayers set below
+	// loggingLayer, so that credentials are not logged. The limits are shared
+	// by all of them.
+	baseTransport := newBaseTransport(tlsConfig)
+	limit := limitLayer(
+		d.Get("requests_per_second").(float64),
+		d.Get("max_concurrent_requests").(int),
+	)
+	retry := retryLayer(
+		d.Get("max_retries").(int),
+		time.Duration(d.Get("retry_min_wait").(int))*time.Second,
+		time.Duration(d.Get("retry_max_wait").(int))*time.Second,
+	)
+	newTransport := func(authLayers ...transportLayer) http.RoundTripper {
+		layers := append([]transportLayer{headerLayer(userAgent, headers)}, authLayers...)
+		layers = append(layers, limit, loggingLayer, retry)
+
+		return chainTransport(baseTransport, layers...)
+	}
+
+	// transport is shared by the API and token clients, the API client adds
+	// apiLayers on top of it once authentication is known.
+	transport := newTransport()
+	var apiLayers []transportLayer
+
 	client := &http.Client{
 		Transport: transport,
 	}
@@ -105,6 +292,16 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}
 
 	//path := strings.TrimSuffix(u.Path, "/")
 
+	apiVersion := d.Get("api_version").(string)
+	var airflowVersion *airflow.VersionInfo
+	if apiVersion == "" {
+		apiVersion, airflowVersion, err = detectAPIVersion(ctx, &http.Client{Transport: transport}, endpoint)
+		if err != nil {
+			return nil, diag.Errorf("failed to detect the Airflow version at %s: %s", endpoint, err)
+		}
+		log.Printf("[DEBUG] Using the Airflow API %s", apiVersion)
+	}
+
 	clientConf := &airflow.Configuration{
 		Scheme:     u.Scheme,
 		Host:       u.Host,
@@ -124,44 +321,103 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}
 		}))
 	}
 
+	if clientId, ok := d.GetOk("oauth2_client_id"); ok {
+		log.Printf("[DEBUG] Using API OAuth2 client credentials")
+
+		ccConf := &clientcredentials.Config{
+			ClientID:     clientId.(string),
+			ClientSecret: d.Get("oauth2_client_secret").(string),
+			TokenURL:     d.Get("oauth2_token_url").(string),
+		}
+		for _, scope := range d.Get("oauth2_scopes").([]interface{}) {
+			ccConf.Scopes = append(ccConf.Scopes, scope.(string))
+		}
+
+		// The token endpoint is reached through the same transport as the API,
+		// without the headers meant for Airflow.
+		tokenCtx := context.WithValue(context.Background(), oauth2.HTTPClient, &http.Client{
+			Transport: chainTransport(transport, externalLayer),
+		})
+		ctx = context.WithValue(ctx, airflow.ContextOAuth2, ccConf.TokenSource(tokenCtx))
+	}
+
+	if v, ok := d.GetOk("token_command"); ok {
+		var command []string
+		for _, arg := range v.([]interface{}) {
+			command = append(command, arg.(string))
+		}
+		log.Printf("[DEBUG] Using API token command")
+
+		tokenSource := newCommandTokenSource(command, endpoint, tokenCacheDir())
+		apiLayers = append(apiLayers, reauthLayer(tokenSource))
+		ctx = context.WithValue(ctx, airflow.ContextOAuth2, oauth2.TokenSource(tokenSource))
+	}
+
+	if v, ok := d.GetOk("token_file"); ok {
+		log.Printf("[DEBUG] Using API token file")
+		ctx = context.WithValue(ctx, airflow.ContextOAuth2, oauth2.TokenSource(newFileTokenSource(v.(string))))
+	}
+
+	_, hasCredentials := d.GetOk("username")
+	authMode, err := resolveAuthMode(d.Get("auth_mode").(string), hasCredentials, apiVersion)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+
 	if username, ok := d.GetOk("username"); ok {
 		var password interface{}
 		if password, ok = d.GetOk("password"); !ok {
 			return nil, diag.Errorf("found username for basic auth, but password not specified")
 		}
-		log.Printf("[DEBUG] Using API Basic Auth")
+		log.Printf("[DEBUG] Using API %s authentication", authMode)
 
-		loginBody := *auth.NewLoginBody(username.(string), password.(string))
-
-		configuration := &auth.Configuration{
-			Scheme:     u.Scheme,
-			Host:       u.Host,
-			Debug:      true,
-			HTTPClient: client,
-			Servers: auth.ServerConfigurations{
-				{
-					URL:         strings.TrimSuffix(endpoint, "/"),
-					Description: "Apache Airflow Stable API.",
-				},
-			},
+		tokenClient := &http.Client{
+			Transport: transport,
 		}
 
-		apiClient := auth.NewAPIClient(configuration)
-		resp, _, err := apiClient.SimpleAuthManagerLoginAPI.CreateToken(ctx).LoginBody(loginBody).Execute()
-		if err != nil {
-			return nil, diag.Errorf("Error when calling `SimpleAuthManagerLoginAPI.CreateToken``: %v %v\n", err, endpoint)
+		var login func() (string, error)
+		switch authMode {
+		case authModeBasic:
+			// The API client sends the password with every request, from a
+			// transport of its own so that it is not logged.
+			transport = newTransport(basicAuthLayer(username.(string), password.(string)))
+		case authModeFabJWT:
+			login = fabJWTLogin(tokenClient, endpoint, username.(string), password.(string))
+		default:
+			login = simpleJWTLogin(tokenClient, endpoint, username.(string), password.(string))
 		}
 
-		tokenSource := oauth2.StaticTokenSource(&oauth2.Token{
-			AccessToken: resp.AccessToken,
-		})
+		if login != nil {
+			tokenSource := newLoginTokenSource(login)
+
+			// Log in once now so that wrong credentials fail the configuration.
+			if _, err := tokenSource.Token(); err != nil {
+				return nil, diag.Errorf("%s %s", err, endpoint)
+			}
 
-		ctx = context.WithValue(ctx, airflow.ContextOAuth2, tokenSource)
+			apiLayers = append(apiLayers, reauthLayer(tokenSource))
+			ctx = context.WithValue(ctx, airflow.ContextOAuth2, oauth2.TokenSource(tokenSource))
+		}
 	}
 
+	client.Transport = chainTransport(transport, apiLayers...)
+
+	apiClient := airflow.NewAPIClient(clientConf)
 	prov := ProviderConfig{
-		ApiClient:   airflow.NewAPIClient(clientConf),
-		AuthContext: ctx,
+		ApiClient:      apiClient,
+		APIVersion:     apiVersion,
+		AirflowVersion: airflowVersion,
+		AuthContext:    ctx,
+	}
+
+	// Airflow 2 serves the FAB endpoints as part of its stable API.
+	basePath := strings.TrimSuffix(endpoint, "/")
+	if apiVersion == apiVersionV1 {
+		prov.API = newAPIV1(client, basePath+"/api/v1")
+		prov.FabClient = newFabClient(client, basePath+"/api/v1")
+	} else {
+		prov.API = newAPIV2(apiClient)
+		prov.FabClient = newFabClient(client, basePath+"/auth/fab/v1")
 	}
 
 	return prov, diag.Diagnostics{}
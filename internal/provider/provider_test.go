@@ -1,17 +1,22 @@
 package provider
 
 import (
+	"context"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"testing"
 
+	"github.com/gbloisi-openaire/airflow-client-go/airflow"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"golang.org/x/oauth2"
 )
 
 var testAccProviders map[string]*schema.Provider
 var testAccProvider *schema.Provider
 
 func init() {
-	testAccProvider = AirflowProvider()
+	testAccProvider = AirflowProvider("test")
 	testAccProviders = map[string]*schema.Provider{
 		"airflow": testAccProvider,
 	}
@@ -24,7 +29,55 @@ func TestProvider(t *testing.T) {
 }
 
 func TestProvider_impl(t *testing.T) {
-	var _ *schema.Provider = AirflowProvider()
+	var _ *schema.Provider = AirflowProvider("test")
+}
+
+func TestProviderConfigure_clientCredentials(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("failed to parse token request: %s", err)
+		}
+		if grantType := r.PostForm.Get("grant_type"); grantType != "client_credentials" {
+			t.Errorf("expected grant_type client_credentials, got %q", grantType)
+		}
+		if scope := r.PostForm.Get("scope"); scope != "airflow.read airflow.write" {
+			t.Errorf("expected the configured scopes, got %q", scope)
+		}
+		if id, secret, _ := r.BasicAuth(); id != "client" || secret != "secret" {
+			t.Errorf("expected client credentials client/secret, got %s/%s", id, secret)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"access_token":"machine-token","token_type":"Bearer","expires_in":3600}`))
+	}))
+	defer server.Close()
+
+	d := schema.TestResourceDataRaw(t, AirflowProvider("test").Schema, map[string]interface{}{
+		"base_endpoint":        "http://localhost:8080",
+		"oauth2_client_id":     "client",
+		"oauth2_client_secret": "secret",
+		"oauth2_token_url":     server.URL + "/token",
+		"oauth2_scopes":        []interface{}{"airflow.read", "airflow.write"},
+		"api_version":          "v2",
+	})
+
+	meta, diags := providerConfigure(context.Background(), d, "")
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	ts, ok := meta.(ProviderConfig).AuthContext.Value(airflow.ContextOAuth2).(oauth2.TokenSource)
+	if !ok {
+		t.Fatal("expected a token source in the auth context")
+	}
+
+	token, err := ts.Token()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if token.AccessToken != "machine-token" {
+		t.Fatalf("expected the token from the token endpoint, got %q", token.AccessToken)
+	}
 }
 
 func testAccPreCheck(t *testing.T) {
@@ -0,0 +1,94 @@
+package provider
+
+import (
+	"io"
+	"math"
+	"net/http"
+	"sync"
+
+	"golang.org/x/time/rate"
+)
+
+// limitTransport throttles requests to a rate and caps the number of
+// requests in flight. A request holds its slot until its response body is
+// closed, so slow downloads count against the cap too.
+type limitTransport struct {
+	next    http.RoundTripper
+	limiter *rate.Limiter
+	slots   chan struct{}
+}
+
+// limitLayer returns a layer whose transports all share the same limits, so
+// that clients built from it count against the same budget.
+func limitLayer(requestsPerSecond float64, maxConcurrent int) transportLayer {
+	if requestsPerSecond <= 0 && maxConcurrent <= 0 {
+		return func(next http.RoundTripper) http.RoundTripper {
+			return next
+		}
+	}
+
+	var limiter *rate.Limiter
+	if requestsPerSecond > 0 {
+		limiter = rate.NewLimiter(rate.Limit(requestsPerSecond), int(math.Max(1, math.Ceil(requestsPerSecond))))
+	}
+	var slots chan struct{}
+	if maxConcurrent > 0 {
+		slots = make(chan struct{}, maxConcurrent)
+	}
+
+	return func(next http.RoundTripper) http.RoundTripper {
+		return &limitTransport{
+			next:    next,
+			limiter: limiter,
+			slots:   slots,
+		}
+	}
+}
+
+func (t *limitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	release := func() {}
+
+	if t.slots != nil {
+		select {
+		case t.slots <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+
+		var once sync.Once
+		release = func() {
+			once.Do(func() { <-t.slots })
+		}
+	}
+
+	if t.limiter != nil {
+		if err := t.limiter.Wait(ctx); err != nil {
+			release()
+			return nil, err
+		}
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil || resp.Body == nil {
+		release()
+		return resp, err
+	}
+
+	resp.Body = &releasingBody{ReadCloser: resp.Body, release: release}
+
+	return resp, nil
+}
+
+// releasingBody calls release once the body is closed.
+type releasingBody struct {
+	io.ReadCloser
+	release func()
+}
+
+func (b *releasingBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.release()
+
+	return err
+}
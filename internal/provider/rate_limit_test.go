@@ -0,0 +1,81 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestLimitTransport_maxConcurrentRequests(t *testing.T) {
+	var inFlight, maxInFlight int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		for {
+			observed := atomic.LoadInt32(&maxInFlight)
+			if n <= observed || atomic.CompareAndSwapInt32(&maxInFlight, observed, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		atomic.AddInt32(&inFlight, -1)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: limitLayer(0, 2)(http.DefaultTransport)}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := client.Get(server.URL)
+			if err != nil {
+				t.Errorf("unexpected error: %s", err)
+				return
+			}
+			resp.Body.Close()
+		}()
+	}
+	wg.Wait()
+
+	if maxInFlight > 2 {
+		t.Fatalf("expected at most 2 concurrent requests, got %d", maxInFlight)
+	}
+}
+
+func TestLimitTransport_requestsPerSecond(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	d := schema.TestResourceDataRaw(t, AirflowProvider("test").Schema, map[string]interface{}{
+		"base_endpoint":       server.URL,
+		"oauth2_token":        "token",
+		"requests_per_second": 20.0,
+	})
+
+	meta, diags := providerConfigure(context.Background(), d, "")
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	client := meta.(ProviderConfig).ApiClient.GetConfig().HTTPClient
+
+	// The first 20 requests use the burst, the next 10 need half a second.
+	start := time.Now()
+	for i := 0; i < 30; i++ {
+		resp, err := client.Get(server.URL)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		resp.Body.Close()
+	}
+
+	if elapsed := time.Since(start); elapsed < 400*time.Millisecond {
+		t.Fatalf("expected requests to be throttled, 30 requests took %s", elapsed)
+	}
+}
@@ -0,0 +1,165 @@
+package provider
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"sync"
+
+	"github.com/gbloisi-openaire/airflow-client-go/airflow"
+)
+
+// listPageSize is the page size used to list collections, it matches
+// the default maximum page limit of the Airflow API.
+const listPageSize = 100
+
+// readCache serves the reads of variables, connections and pools from a
+// single listing of each collection, so that refreshing thousands of
+// resources costs a few paginated requests instead of one per resource.
+type readCache struct {
+	variables   *collectionCache[airflow.VariableResponse]
+	connections *collectionCache[airflow.ConnectionResponse]
+	pools       *collectionCache[airflow.PoolResponse]
+}
+
+// collectionCache lazily lists a collection on the first lookup. Entries
+// written by the provider afterwards are marked stale and read directly, so
+// the listing never hides a change made during the same run.
+type collectionCache[T any] struct {
+	mu       sync.Mutex
+	list     func(ctx context.Context) (map[string]T, error)
+	get      func(ctx context.Context, key string) (*T, *http.Response, error)
+	items    map[string]T
+	stale    map[string]bool
+	disabled bool
+}
+
+func newReadCache(client *airflow.APIClient) *readCache {
+	return &readCache{
+		variables: &collectionCache[airflow.VariableResponse]{
+			list: func(ctx context.Context) (map[string]airflow.VariableResponse, error) {
+				items := map[string]airflow.VariableResponse{}
+				for offset := int32(0); ; {
+					page, _, err := client.VariableAPI.GetVariables(ctx).Limit(listPageSize).Offset(offset).Execute()
+					if err != nil {
+						return nil, err
+					}
+					for _, v := range page.Variables {
+						items[v.Key] = v
+					}
+					offset += int32(len(page.Variables))
+					if len(page.Variables) == 0 || offset >= page.TotalEntries {
+						return items, nil
+					}
+				}
+			},
+			get: func(ctx context.Context, key string) (*airflow.VariableResponse, *http.Response, error) {
+				return client.VariableAPI.GetVariable(ctx, key).Execute()
+			},
+		},
+		connections: &collectionCache[airflow.ConnectionResponse]{
+			list: func(ctx context.Context) (map[string]airflow.ConnectionResponse, error) {
+				items := map[string]airflow.ConnectionResponse{}
+				for offset := int32(0); ; {
+					page, _, err := client.ConnectionAPI.GetConnections(ctx).Limit(listPageSize).Offset(offset).Execute()
+					if err != nil {
+						return nil, err
+					}
+					for _, c := range page.Connections {
+						items[c.ConnectionId] = c
+					}
+					offset += int32(len(page.Connections))
+					if len(page.Connections) == 0 || offset >= page.TotalEntries {
+						return items, nil
+					}
+				}
+			},
+			get: func(ctx context.Context, key string) (*airflow.ConnectionResponse, *http.Response, error) {
+				return client.ConnectionAPI.GetConnection(ctx, key).Execute()
+			},
+		},
+		pools: &collectionCache[airflow.PoolResponse]{
+			list: func(ctx context.Context) (map[string]airflow.PoolResponse, error) {
+				items := map[string]airflow.PoolResponse{}
+				for offset := int32(0); ; {
+					page, _, err := client.PoolAPI.GetPools(ctx).Limit(listPageSize).Offset(offset).Execute()
+					if err != nil {
+						return nil, err
+					}
+					for _, p := range page.Pools {
+						items[p.Name] = p
+					}
+					offset += int32(len(page.Pools))
+					if len(page.Pools) == 0 || offset >= page.TotalEntries {
+						return items, nil
+					}
+				}
+			},
+			get: func(ctx context.Context, key string) (*airflow.PoolResponse, *http.Response, error) {
+				return client.PoolAPI.GetPool(ctx, key).Execute()
+			},
+		},
+	}
+}
+
+// lookup returns the entry for key, and whether it exists.
+func (c *collectionCache[T]) lookup(ctx context.Context, key string) (*T, bool, error) {
+	if item, found, ok := c.cached(ctx, key); ok {
+		return item, found, nil
+	}
+
+	return c.fetch(ctx, key)
+}
+
+// fetch reads the entry for key directly, without listing the collection.
+func (c *collectionCache[T]) fetch(ctx context.Context, key string) (*T, bool, error) {
+	item, resp, err := c.get(ctx, key)
+	if resp != nil && resp.StatusCode == 404 {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	return item, true, nil
+}
+
+// cached answers from the listing, ok is false when it cannot.
+func (c *collectionCache[T]) cached(ctx context.Context, key string) (item *T, found bool, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.disabled || c.stale[key] {
+		return nil, false, false
+	}
+
+	if c.items == nil {
+		items, err := c.list(ctx)
+		if err != nil {
+			// Listing may be forbidden while single reads are not.
+			log.Printf("[WARN] Failed to list the collection, reading entries one by one: %s", err)
+			c.disabled = true
+			return nil, false, false
+		}
+		c.items = items
+	}
+
+	v, found := c.items[key]
+	if !found {
+		return nil, false, true
+	}
+
+	return &v, true, true
+}
+
+// invalidate must be called before reading back an entry the provider wrote.
+func (c *collectionCache[T]) invalidate(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.stale == nil {
+		c.stale = map[string]bool{}
+	}
+	c.stale[key] = true
+	delete(c.items, key)
+}
@@ -0,0 +1,115 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestReadCache_variables(t *testing.T) {
+	const total = 150
+	var listCalls, getCalls int
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if r.URL.Path == "/api/v2/variables" {
+			listCalls++
+			offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+			limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+
+			variables := []map[string]interface{}{}
+			for i := offset; i < total && i < offset+limit; i++ {
+				variables = append(variables, map[string]interface{}{
+					"key": fmt.Sprintf("var_%d", i), "value": "listed", "description": nil, "is_encrypted": false,
+				})
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{"variables": variables, "total_entries": total})
+			return
+		}
+
+		getCalls++
+		key := strings.TrimPrefix(r.URL.Path, "/api/v2/variables/")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"key": key, "value": "fetched", "description": nil, "is_encrypted": false,
+		})
+	}))
+	defer server.Close()
+
+	d := schema.TestResourceDataRaw(t, AirflowProvider("test").Schema, map[string]interface{}{
+		"base_endpoint": server.URL,
+		"oauth2_token":  "token",
+		"api_version":   "v2",
+	})
+	meta, diags := providerConfigure(context.Background(), d, "")
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	pcfg := meta.(ProviderConfig)
+	cache := pcfg.API.(*apiV2).cache.variables
+
+	for _, key := range []string{"var_0", "var_120", "var_149"} {
+		variable, found, err := cache.lookup(pcfg.AuthContext, key)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if !found || variable.Key != key || variable.Value != "listed" {
+			t.Fatalf("expected %s to be served from the listing, got %v", key, variable)
+		}
+	}
+
+	if _, found, err := cache.lookup(pcfg.AuthContext, "missing"); err != nil || found {
+		t.Fatalf("expected a key absent from the listing not to be found, got %t %v", found, err)
+	}
+
+	if listCalls != 2 || getCalls != 0 {
+		t.Fatalf("expected 2 list calls and no get, got %d and %d", listCalls, getCalls)
+	}
+
+	cache.invalidate("var_0")
+	variable, found, err := cache.lookup(pcfg.AuthContext, "var_0")
+	if err != nil || !found || variable.Value != "fetched" {
+		t.Fatalf("expected an invalidated key to be fetched, got %v %t %v", variable, found, err)
+	}
+	if getCalls != 1 {
+		t.Fatalf("expected 1 get call, got %d", getCalls)
+	}
+}
+
+func TestDataSourceVariableRead_bypassesReadCache(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v2/variables/example" {
+			t.Errorf("expected a single variable read, got %s %s", r.Method, r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"key":"example","value":"value","description":null,"is_encrypted":false}`))
+	}))
+	defer server.Close()
+
+	d := schema.TestResourceDataRaw(t, AirflowProvider("test").Schema, map[string]interface{}{
+		"base_endpoint": server.URL,
+		"oauth2_token":  "token",
+		"api_version":   "v2",
+	})
+	meta, diags := providerConfigure(context.Background(), d, "")
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	variable := dataSourceVariable().TestResourceData()
+	variable.Set("key", "example")
+	if diags := dataSourceVariableRead(context.Background(), variable, meta); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if variable.Get("value").(string) != "value" {
+		t.Fatalf("unexpected value %q", variable.Get("value"))
+	}
+}
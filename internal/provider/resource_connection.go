@@ -85,7 +85,6 @@ func suppressSameJsonDiff(k, oldo, newo string, d *schema.ResourceData) bool {
 
 func resourceConnectionCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	pcfg := m.(ProviderConfig)
-	client := pcfg.ApiClient
 	connId := d.Get("connection_id").(string)
 	connType := d.Get("conn_type").(string)
 
@@ -120,15 +119,13 @@ func resourceConnectionCreate(ctx context.Context, d *schema.ResourceData, m int
 		conn.SetExtra(v.(string))
 	}
 
-	connApi := client.ConnectionAPI
-
-	_, res, err := connApi.PostConnection(pcfg.AuthContext).ConnectionBody(*conn).Execute()
+	res, err := pcfg.API.CreateConnection(pcfg.AuthContext, *conn)
 	if err != nil {
 		if res != nil && res.StatusCode == 409 {
 			// Try to fetch the existing pool to adopt it
-			existingConnection, _, getErr := connApi.GetConnection(pcfg.AuthContext, connId).Execute()
-			if getErr != nil {
-				return diag.Errorf("connection `%s` already exists, but failed to fetch it: %s", connId, getErr)
+			existingConnection, found, getErr := pcfg.API.LookupConnection(pcfg.AuthContext, connId)
+			if getErr != nil || !found {
+				return diag.Errorf("connection `%s` already exists, but failed to fetch it: %v", connId, getErr)
 			}
 
 			// Adopt the existing variable
@@ -147,15 +144,14 @@ func resourceConnectionCreate(ctx context.Context, d *schema.ResourceData, m int
 
 func resourceConnectionRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	pcfg := m.(ProviderConfig)
-	client := pcfg.ApiClient
-	connection, resp, err := client.ConnectionAPI.GetConnection(pcfg.AuthContext, d.Id()).Execute()
-	if resp != nil && resp.StatusCode == 404 {
-		d.SetId("")
-		return nil
-	}
+	connection, found, err := pcfg.API.LookupConnection(pcfg.AuthContext, d.Id())
 	if err != nil {
 		return diag.Errorf("failed to get connection `%s` from Airflow: %s", d.Id(), err)
 	}
+	if !found {
+		d.SetId("")
+		return nil
+	}
 
 	d.Set("connection_id", connection.GetConnectionId())
 	d.Set("conn_type", connection.GetConnType())
@@ -177,7 +173,6 @@ func resourceConnectionRead(ctx context.Context, d *schema.ResourceData, m inter
 
 func resourceConnectionUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	pcfg := m.(ProviderConfig)
-	client := pcfg.ApiClient
 	connId := d.Id()
 	connType := d.Get("conn_type").(string)
 
@@ -226,7 +221,7 @@ func resourceConnectionUpdate(ctx context.Context, d *schema.ResourceData, m int
 		conn.SetExtraNil()
 	}
 
-	_, _, err := client.ConnectionAPI.PatchConnection(pcfg.AuthContext, connId).ConnectionBody(*conn).Execute()
+	_, err := pcfg.API.UpdateConnection(pcfg.AuthContext, connId, *conn)
 	if err != nil {
 		return diag.Errorf("failed to update connection `%s` from Airflow: %s", connId, err)
 	}
@@ -236,9 +231,8 @@ func resourceConnectionUpdate(ctx context.Context, d *schema.ResourceData, m int
 
 func resourceConnectionDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	pcfg := m.(ProviderConfig)
-	client := pcfg.ApiClient
 
-	resp, err := client.ConnectionAPI.DeleteConnection(pcfg.AuthContext, d.Id()).Execute()
+	resp, err := pcfg.API.DeleteConnection(pcfg.AuthContext, d.Id())
 	if err != nil {
 		return diag.Errorf("failed to delete connection `%s` from Airflow: %s", d.Id(), err)
 	}
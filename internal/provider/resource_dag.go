@@ -3,7 +3,6 @@ package provider
 import (
 	"context"
 
-	"github.com/gbloisi-openaire/airflow-client-go/airflow"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -62,17 +61,11 @@ func resourceDag() *schema.Resource {
 
 func resourceDagUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	pcfg := m.(ProviderConfig)
-	client := pcfg.ApiClient
 
 	dagId := d.Get("dag_id").(string)
-	dagApi := client.DAGAPI
-	dag := airflow.DAGPatchBody{
-		IsPaused: d.Get("is_paused").(bool),
-	}
-	dag.SetIsPaused(d.Get("is_paused").(bool))
 
-	_, res, err := dagApi.PatchDag(pcfg.AuthContext, dagId).DAGPatchBody(dag).Execute()
-	if res.StatusCode != 200 {
+	res, err := pcfg.API.PatchDag(pcfg.AuthContext, dagId, d.Get("is_paused").(bool))
+	if res == nil || res.StatusCode != 200 {
 		return diag.Errorf("failed to update DAG `%s` from Airflow: %s", dagId, err)
 	}
 	d.SetId(dagId)
@@ -82,14 +75,13 @@ func resourceDagUpdate(ctx context.Context, d *schema.ResourceData, m interface{
 
 func resourceDagRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	pcfg := m.(ProviderConfig)
-	client := pcfg.ApiClient
 
-	DAG, resp, err := client.DAGAPI.GetDag(pcfg.AuthContext, d.Id()).Execute()
+	DAG, resp, err := pcfg.API.GetDag(pcfg.AuthContext, d.Id())
 	if resp != nil && resp.StatusCode == 404 {
 		d.SetId("")
 		return nil
 	}
-	if resp.StatusCode != 200 {
+	if resp == nil || resp.StatusCode != 200 {
 		return diag.Errorf("failed to get DAG `%s` from Airflow: %s", d.Id(), err)
 	}
 
@@ -107,11 +99,10 @@ func resourceDagRead(ctx context.Context, d *schema.ResourceData, m interface{})
 
 func resourceDagDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	pcfg := m.(ProviderConfig)
-	client := pcfg.ApiClient.DAGAPI
 
 	if d.Get("delete_dag").(bool) {
 
-		_, resp, err := client.DeleteDag(pcfg.AuthContext, d.Id()).Execute()
+		resp, err := pcfg.API.DeleteDag(pcfg.AuthContext, d.Id())
 		if err != nil {
 			return diag.Errorf("failed to delete DAG `%s` from Airflow: %s", d.Id(), err)
 		}
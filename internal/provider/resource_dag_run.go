@@ -6,7 +6,6 @@ import (
 	"strings"
 	"time"
 
-	"github.com/gbloisi-openaire/airflow-client-go/airflow"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -51,23 +50,16 @@ func resourceDagRun() *schema.Resource {
 
 func resourceDagRunCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	pcfg := m.(ProviderConfig)
-	client := pcfg.ApiClient.DagRunAPI
 
 	dagId := d.Get("dag_id").(string)
-	dagRun := *airflow.NewTriggerDAGRunPostBodyWithDefaults()
-
-	if v, ok := d.GetOk("dag_run_id"); ok {
-		grid := v.(string)
-
-		dagRun.SetDagRunId(airflow.DagRunId{
-			String: &grid})
-	}
+	dagRunId := d.Get("dag_run_id").(string)
 
+	var conf map[string]interface{}
 	if v, ok := d.GetOk("conf"); ok {
-		dagRun.SetConf(v.(map[string]interface{}))
+		conf = v.(map[string]interface{})
 	}
 
-	res, _, err := client.TriggerDagRun(pcfg.AuthContext, dagId).TriggerDAGRunPostBody(dagRun).Execute()
+	res, _, err := pcfg.API.TriggerDagRun(pcfg.AuthContext, dagId, dagRunId, conf)
 	if err != nil {
 		return diag.Errorf("failed to create Dag Run `%s` from Airflow: %s", dagId, err)
 	}
@@ -76,7 +68,7 @@ func resourceDagRunCreate(ctx context.Context, d *schema.ResourceData, m interfa
 	stateConf := &resource.StateChangeConf{
 		Pending: []string{"queued", "running", "success"},
 		Target:  []string{"success"},
-		Refresh: resourceDagRunStateRefreshFunc(d.Id(), pcfg.AuthContext, client),
+		Refresh: resourceDagRunStateRefreshFunc(d.Id(), pcfg.AuthContext, pcfg.API),
 		Timeout: d.Timeout(schema.TimeoutCreate),
 	}
 
@@ -90,14 +82,13 @@ func resourceDagRunCreate(ctx context.Context, d *schema.ResourceData, m interfa
 
 func resourceDagRunRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	pcfg := m.(ProviderConfig)
-	client := pcfg.ApiClient.DagRunAPI
 
 	dagId, dagRunId, err := airflowDagRunId(d.Id())
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	dagRun, resp, err := client.GetDagRun(pcfg.AuthContext, dagId, dagRunId).Execute()
+	dagRun, resp, err := pcfg.API.GetDagRun(pcfg.AuthContext, dagId, dagRunId)
 	if resp != nil && resp.StatusCode == 404 {
 		d.SetId("")
 		return nil
@@ -116,14 +107,13 @@ func resourceDagRunRead(ctx context.Context, d *schema.ResourceData, m interface
 
 func resourceDagRunDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	pcfg := m.(ProviderConfig)
-	client := pcfg.ApiClient.DagRunAPI
 
 	dagId, dagRunId, err := airflowDagRunId(d.Id())
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	resp, err := client.DeleteDagRun(pcfg.AuthContext, dagId, dagRunId).Execute()
+	resp, err := pcfg.API.DeleteDagRun(pcfg.AuthContext, dagId, dagRunId)
 	if err != nil {
 		return diag.Errorf("failed to delete dagRunId `%s` from Airflow: %s", d.Id(), err)
 	}
@@ -145,14 +135,14 @@ func airflowDagRunId(id string) (string, string, error) {
 	return parts[0], parts[1], nil
 }
 
-func resourceDagRunStateRefreshFunc(id string, pcfg context.Context, client *airflow.DagRunAPIService) resource.StateRefreshFunc {
+func resourceDagRunStateRefreshFunc(id string, pcfg context.Context, client airflowAPI) resource.StateRefreshFunc {
 	return func() (interface{}, string, error) {
 		dagId, dagRunId, err := airflowDagRunId(id)
 		if err != nil {
 			return nil, "", err
 		}
 
-		dagRun, _, err := client.GetDagRun(pcfg, dagId, dagRunId).Execute()
+		dagRun, _, err := client.GetDagRun(pcfg, dagId, dagRunId)
 		if err != nil {
 			return nil, "", fmt.Errorf("failed to get Dag Run `%s` from Airflow: %s", dagRunId, err)
 		}
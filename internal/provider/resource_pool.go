@@ -49,24 +49,22 @@ func resourcePool() *schema.Resource {
 
 func resourcePoolCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	pcfg := m.(ProviderConfig)
-	client := pcfg.ApiClient
 
 	name := d.Get("name").(string)
 	slots := int32(d.Get("slots").(int))
-	varApi := client.PoolAPI
 
 	pool := airflow.PoolBody{
 		Name:  name,
 		Slots: slots,
 	}
 
-	_, resp, err := varApi.PostPool(pcfg.AuthContext).PoolBody(pool).Execute()
+	resp, err := pcfg.API.CreatePool(pcfg.AuthContext, pool)
 	if err != nil {
 		if resp != nil && resp.StatusCode == 409 {
 			// Try to fetch the existing pool to adopt it
-			existingPool, _, getErr := varApi.GetPool(pcfg.AuthContext, name).Execute()
-			if getErr != nil {
-				return diag.Errorf("pool `%s` already exists, but failed to fetch it: %s", name, getErr)
+			existingPool, found, getErr := pcfg.API.LookupPool(pcfg.AuthContext, name)
+			if getErr != nil || !found {
+				return diag.Errorf("pool `%s` already exists, but failed to fetch it: %v", name, getErr)
 			}
 
 			// Adopt the existing pool
@@ -90,16 +88,15 @@ func resourcePoolCreate(ctx context.Context, d *schema.ResourceData, m interface
 
 func resourcePoolRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	pcfg := m.(ProviderConfig)
-	client := pcfg.ApiClient
 
-	pool, resp, err := client.PoolAPI.GetPool(pcfg.AuthContext, d.Id()).Execute()
-	if resp != nil && resp.StatusCode == 404 {
-		d.SetId("")
-		return nil
-	}
+	pool, found, err := pcfg.API.LookupPool(pcfg.AuthContext, d.Id())
 	if err != nil {
 		return diag.Errorf("failed to get pool `%s` from Airflow: %s", d.Id(), err)
 	}
+	if !found {
+		d.SetId("")
+		return nil
+	}
 
 	d.Set("name", pool.Name)
 	d.Set("slots", pool.Slots)
@@ -113,16 +110,11 @@ func resourcePoolRead(ctx context.Context, d *schema.ResourceData, m interface{}
 
 func resourcePoolUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	pcfg := m.(ProviderConfig)
-	client := pcfg.ApiClient
 
 	slots := int32(d.Get("slots").(int))
 	name := d.Id()
 
-	pool := airflow.PoolPatchBody{
-		Slots: *airflow.NewNullableInt32(&slots),
-	}
-
-	_, _, err := client.PoolAPI.PatchPool(pcfg.AuthContext, name).PoolPatchBody(pool).UpdateMask([]string{"slots"}).Execute()
+	_, err := pcfg.API.UpdatePoolSlots(pcfg.AuthContext, name, slots)
 	if err != nil {
 		return diag.Errorf("failed to update pool `%s` from Airflow: %s", name, err)
 	}
@@ -132,14 +124,13 @@ func resourcePoolUpdate(ctx context.Context, d *schema.ResourceData, m interface
 
 func resourcePoolDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	pcfg := m.(ProviderConfig)
-	client := pcfg.ApiClient
 
 	if d.Id() == "default_pool" {
 		// default pool cannot be deleted
 		return nil
 	}
 
-	resp, err := client.PoolAPI.DeletePool(pcfg.AuthContext, d.Id()).Execute()
+	resp, err := pcfg.API.DeletePool(pcfg.AuthContext, d.Id())
 	if err != nil {
 		return diag.Errorf("failed to delete pool `%s` from Airflow: %s", d.Id(), err)
 	}
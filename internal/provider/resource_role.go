@@ -0,0 +1,179 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceRole() *schema.Resource {
+	return &schema.Resource{
+		CreateWithoutTimeout: resourceRoleCreate,
+		ReadWithoutTimeout:   resourceRoleRead,
+		UpdateWithoutTimeout: resourceRoleUpdate,
+		DeleteWithoutTimeout: resourceRoleDelete,
+		CustomizeDiff:        resourceRoleCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"action": {
+				Type:     schema.TypeSet,
+				Required: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"action": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"resource": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceRoleCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	pcfg := m.(ProviderConfig)
+	client := pcfg.FabClient
+
+	name := d.Get("name").(string)
+	role := fabRole{
+		Name:    name,
+		Actions: expandAirflowRoleActions(d.Get("action").(*schema.Set).List()),
+	}
+
+	_, resp, err := client.PostRole(pcfg.AuthContext, role)
+	if err != nil {
+		if resp != nil && resp.StatusCode == 409 {
+			// Try to fetch the existing role to adopt it
+			existingRole, _, getErr := client.GetRole(pcfg.AuthContext, name)
+			if getErr != nil {
+				return diag.Errorf("role `%s` already exists, but failed to fetch it: %s", name, getErr)
+			}
+
+			// Adopt the existing role
+			d.SetId(existingRole.Name)
+
+			// Always try to update to be indempotent
+			return resourceRoleUpdate(ctx, d, m)
+		}
+
+		return diag.Errorf("failed to create role `%s` from Airflow: %s", name, err)
+	}
+
+	d.SetId(name)
+
+	return resourceRoleRead(ctx, d, m)
+}
+
+func resourceRoleRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	pcfg := m.(ProviderConfig)
+	client := pcfg.FabClient
+
+	role, resp, err := client.GetRole(pcfg.AuthContext, d.Id())
+	if resp != nil && resp.StatusCode == 404 {
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return diag.Errorf("failed to get role `%s` from Airflow: %s", d.Id(), err)
+	}
+
+	d.Set("name", role.Name)
+	if err := d.Set("action", flattenAirflowRoleActions(role.Actions)); err != nil {
+		return diag.Errorf("failed to set actions of role `%s`: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+func resourceRoleUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	pcfg := m.(ProviderConfig)
+	client := pcfg.FabClient
+
+	name := d.Id()
+	role := fabRole{
+		Name:    name,
+		Actions: expandAirflowRoleActions(d.Get("action").(*schema.Set).List()),
+	}
+
+	_, _, err := client.PatchRole(pcfg.AuthContext, name, role, []string{"actions"})
+	if err != nil {
+		return diag.Errorf("failed to update role `%s` from Airflow: %s", name, err)
+	}
+
+	return resourceRoleRead(ctx, d, m)
+}
+
+// resourceRoleCustomizeDiff replaces the role when actions are removed: the
+// FAB API only adds the actions of an update to the role, it never revokes
+// any, so removals could not be applied in place.
+func resourceRoleCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() == "" || !d.HasChange("action") {
+		return nil
+	}
+
+	o, n := d.GetChange("action")
+	if o.(*schema.Set).Difference(n.(*schema.Set)).Len() > 0 {
+		return d.ForceNew("action")
+	}
+
+	return nil
+}
+
+func resourceRoleDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	pcfg := m.(ProviderConfig)
+	client := pcfg.FabClient
+
+	resp, err := client.DeleteRole(pcfg.AuthContext, d.Id())
+	if resp != nil && resp.StatusCode == 404 {
+		return nil
+	}
+	if err != nil {
+		return diag.Errorf("failed to delete role `%s` from Airflow: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+func expandAirflowRoleActions(tfList []interface{}) []fabActionResource {
+	actions := make([]fabActionResource, 0, len(tfList))
+
+	for _, tfMapRaw := range tfList {
+		tfMap, ok := tfMapRaw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		actions = append(actions, fabActionResource{
+			Action:   fabAction{Name: tfMap["action"].(string)},
+			Resource: fabResource{Name: tfMap["resource"].(string)},
+		})
+	}
+
+	return actions
+}
+
+func flattenAirflowRoleActions(actions []fabActionResource) []interface{} {
+	tfList := make([]interface{}, 0, len(actions))
+
+	for _, action := range actions {
+		tfList = append(tfList, map[string]interface{}{
+			"action":   action.Action.Name,
+			"resource": action.Resource.Name,
+		})
+	}
+
+	return tfList
+}
@@ -0,0 +1,128 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccAirflowRole_basic(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+
+	resourceName := "airflow_role.test"
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAirflowRoleCheckDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAirflowRoleConfigBasic(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", rName),
+					resource.TestCheckResourceAttr(resourceName, "action.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "action.*", map[string]string{
+						"action":   "can_read",
+						"resource": "Audit Logs",
+					}),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccAirflowRoleConfigUpdated(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", rName),
+					resource.TestCheckResourceAttr(resourceName, "action.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "action.*", map[string]string{
+						"action":   "can_read",
+						"resource": "DAGs",
+					}),
+				),
+			},
+			{
+				Config: testAccAirflowRoleConfigRemoved(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", rName),
+					resource.TestCheckResourceAttr(resourceName, "action.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "action.*", map[string]string{
+						"action":   "can_read",
+						"resource": "DAGs",
+					}),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckAirflowRoleCheckDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(ProviderConfig)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "airflow_role" {
+			continue
+		}
+
+		role, res, err := client.FabClient.GetRole(client.AuthContext, rs.Primary.ID)
+		if err == nil {
+			if role.Name == rs.Primary.ID {
+				return fmt.Errorf("Airflow Role (%s) still exists.", rs.Primary.ID)
+			}
+		}
+
+		if res != nil && res.StatusCode == 404 {
+			continue
+		}
+	}
+
+	return nil
+}
+
+func testAccAirflowRoleConfigBasic(rName string) string {
+	return fmt.Sprintf(`
+resource "airflow_role" "test" {
+  name = %[1]q
+
+  action {
+    action   = "can_read"
+    resource = "Audit Logs"
+  }
+}
+`, rName)
+}
+
+func testAccAirflowRoleConfigUpdated(rName string) string {
+	return fmt.Sprintf(`
+resource "airflow_role" "test" {
+  name = %[1]q
+
+  action {
+    action   = "can_read"
+    resource = "Audit Logs"
+  }
+
+  action {
+    action   = "can_read"
+    resource = "DAGs"
+  }
+}
+`, rName)
+}
+
+func testAccAirflowRoleConfigRemoved(rName string) string {
+	return fmt.Sprintf(`
+resource "airflow_role" "test" {
+  name = %[1]q
+
+  action {
+    action   = "can_read"
+    resource = "DAGs"
+  }
+}
+`, rName)
+}
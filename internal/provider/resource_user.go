@@ -0,0 +1,192 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceUser() *schema.Resource {
+	return &schema.Resource{
+		CreateWithoutTimeout: resourceUserCreate,
+		ReadWithoutTimeout:   resourceUserRead,
+		UpdateWithoutTimeout: resourceUserUpdate,
+		DeleteWithoutTimeout: resourceUserDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"active": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"email": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"failed_login_count": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"first_name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"last_name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"login_count": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"password": {
+				Type:      schema.TypeString,
+				Required:  true,
+				Sensitive: true,
+			},
+			"roles": {
+				Type:     schema.TypeSet,
+				Required: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"username": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+		},
+	}
+}
+
+func resourceUserCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	pcfg := m.(ProviderConfig)
+	client := pcfg.FabClient
+
+	username := d.Get("username").(string)
+	user := fabUser{
+		Username:  username,
+		Email:     d.Get("email").(string),
+		FirstName: d.Get("first_name").(string),
+		LastName:  d.Get("last_name").(string),
+		Password:  d.Get("password").(string),
+		Roles:     expandAirflowUserRoles(d.Get("roles").(*schema.Set).List()),
+	}
+
+	_, resp, err := client.PostUser(pcfg.AuthContext, user)
+	if err != nil {
+		if resp != nil && resp.StatusCode == 409 {
+			// Try to fetch the existing user to adopt it
+			existingUser, _, getErr := client.GetUser(pcfg.AuthContext, username)
+			if getErr != nil {
+				return diag.Errorf("user `%s` already exists, but failed to fetch it: %s", username, getErr)
+			}
+
+			// Adopt the existing user
+			d.SetId(existingUser.Username)
+
+			// Always try to update to be indempotent
+			return resourceUserUpdate(ctx, d, m)
+		}
+
+		return diag.Errorf("failed to create user `%s` from Airflow: %s", username, err)
+	}
+
+	d.SetId(username)
+
+	return resourceUserRead(ctx, d, m)
+}
+
+func resourceUserRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	pcfg := m.(ProviderConfig)
+	client := pcfg.FabClient
+
+	user, resp, err := client.GetUser(pcfg.AuthContext, d.Id())
+	if resp != nil && resp.StatusCode == 404 {
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return diag.Errorf("failed to get user `%s` from Airflow: %s", d.Id(), err)
+	}
+
+	d.Set("username", user.Username)
+	d.Set("email", user.Email)
+	d.Set("first_name", user.FirstName)
+	d.Set("last_name", user.LastName)
+	d.Set("active", user.Active)
+	d.Set("failed_login_count", user.FailedLoginCount)
+	d.Set("login_count", user.LoginCount)
+	if err := d.Set("roles", flattenAirflowUserRoles(user.Roles)); err != nil {
+		return diag.Errorf("failed to set roles of user `%s`: %s", d.Id(), err)
+	}
+
+	// The API never returns the password, keep whatever is in the state.
+
+	return nil
+}
+
+func resourceUserUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	pcfg := m.(ProviderConfig)
+	client := pcfg.FabClient
+
+	username := d.Id()
+	user := fabUser{
+		Username:  username,
+		Email:     d.Get("email").(string),
+		FirstName: d.Get("first_name").(string),
+		LastName:  d.Get("last_name").(string),
+		Roles:     expandAirflowUserRoles(d.Get("roles").(*schema.Set).List()),
+	}
+	updateMask := []string{"email", "first_name", "last_name", "roles"}
+
+	// Only send the password when it changed (or when adopting an existing
+	// user), otherwise every update would reset it.
+	if d.HasChange("password") || d.IsNewResource() {
+		user.Password = d.Get("password").(string)
+		updateMask = append(updateMask, "password")
+	}
+
+	_, _, err := client.PatchUser(pcfg.AuthContext, username, user, updateMask)
+	if err != nil {
+		return diag.Errorf("failed to update user `%s` from Airflow: %s", username, err)
+	}
+
+	return resourceUserRead(ctx, d, m)
+}
+
+func resourceUserDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	pcfg := m.(ProviderConfig)
+	client := pcfg.FabClient
+
+	resp, err := client.DeleteUser(pcfg.AuthContext, d.Id())
+	if resp != nil && resp.StatusCode == 404 {
+		return nil
+	}
+	if err != nil {
+		return diag.Errorf("failed to delete user `%s` from Airflow: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+func expandAirflowUserRoles(tfList []interface{}) []fabUserRole {
+	roles := make([]fabUserRole, 0, len(tfList))
+
+	for _, v := range tfList {
+		roles = append(roles, fabUserRole{Name: v.(string)})
+	}
+
+	return roles
+}
+
+func flattenAirflowUserRoles(roles []fabUserRole) []interface{} {
+	tfList := make([]interface{}, 0, len(roles))
+
+	for _, role := range roles {
+		tfList = append(tfList, role.Name)
+	}
+
+	return tfList
+}
@@ -0,0 +1,85 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccAirflowUser_basic(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	rNameUpdated := acctest.RandomWithPrefix("tf-acc-test")
+
+	resourceName := "airflow_user.test"
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAirflowUserCheckDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAirflowUserConfigBasic(rName, rName, "Viewer"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "username", rName),
+					resource.TestCheckResourceAttr(resourceName, "first_name", rName),
+					resource.TestCheckResourceAttr(resourceName, "roles.#", "1"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "roles.*", "Viewer"),
+					resource.TestCheckResourceAttr(resourceName, "active", "true"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"password"},
+			},
+			{
+				Config: testAccAirflowUserConfigBasic(rName, rNameUpdated, "Op"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "username", rName),
+					resource.TestCheckResourceAttr(resourceName, "first_name", rNameUpdated),
+					resource.TestCheckResourceAttr(resourceName, "roles.#", "1"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "roles.*", "Op"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckAirflowUserCheckDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(ProviderConfig)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "airflow_user" {
+			continue
+		}
+
+		user, res, err := client.FabClient.GetUser(client.AuthContext, rs.Primary.ID)
+		if err == nil {
+			if user.Username == rs.Primary.ID {
+				return fmt.Errorf("Airflow User (%s) still exists.", rs.Primary.ID)
+			}
+		}
+
+		if res != nil && res.StatusCode == 404 {
+			continue
+		}
+	}
+
+	return nil
+}
+
+func testAccAirflowUserConfigBasic(rName, firstName, role string) string {
+	return fmt.Sprintf(`
+resource "airflow_user" "test" {
+  username   = %[1]q
+  email      = "%[1]s@example.com"
+  first_name = %[2]q
+  last_name  = %[1]q
+  password   = %[1]q
+  roles      = [%[3]q]
+}
+`, rName, firstName, role)
+}
@@ -37,13 +37,10 @@ func resourceVariable() *schema.Resource {
 
 func resourceVariableCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	pcfg := m.(ProviderConfig)
-	client := pcfg.ApiClient
 
 	key := d.Get("key").(string)
 	val := d.Get("value").(string)
 
-	varApi := client.VariableAPI
-
 	variableReq := airflow.VariableBody{
 		Key:   key,
 		Value: val,
@@ -53,13 +50,13 @@ func resourceVariableCreate(ctx context.Context, d *schema.ResourceData, m inter
 		variableReq.SetDescription(v.(string))
 	}
 
-	_, res, err := varApi.PostVariable(pcfg.AuthContext).VariableBody(variableReq).Execute()
+	res, err := pcfg.API.CreateVariable(pcfg.AuthContext, variableReq)
 	if err != nil {
-		if res.StatusCode == 409 || res.Status == "409 Conflict" {
+		if res != nil && res.StatusCode == 409 {
 			// Try to fetch the existing pool to adopt it
-			existingVariable, _, getErr := varApi.GetVariable(pcfg.AuthContext, key).Execute()
-			if getErr != nil {
-				return diag.Errorf("variable `%s` already exists, but failed to fetch it: %s", key, getErr)
+			existingVariable, found, getErr := pcfg.API.LookupVariable(pcfg.AuthContext, key)
+			if getErr != nil || !found {
+				return diag.Errorf("variable `%s` already exists, but failed to fetch it: %v", key, getErr)
 			}
 
 			// Adopt the existing variable
@@ -73,7 +70,7 @@ func resourceVariableCreate(ctx context.Context, d *schema.ResourceData, m inter
 			return resourceVariableRead(ctx, d, m)
 		}
 
-		return diag.Errorf("failed to create variable `%s`, Status: `%s` from Airflow: %s", key, res.Status, err)
+		return diag.Errorf("failed to create variable `%s`, Status: `%s` from Airflow: %s", key, responseStatus(res), err)
 	}
 
 	d.SetId(key)
@@ -83,16 +80,15 @@ func resourceVariableCreate(ctx context.Context, d *schema.ResourceData, m inter
 
 func resourceVariableRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	pcfg := m.(ProviderConfig)
-	client := pcfg.ApiClient
 
-	variable, resp, err := client.VariableAPI.GetVariable(pcfg.AuthContext, d.Id()).Execute()
-	if resp != nil && resp.StatusCode == 404 {
+	variable, found, err := pcfg.API.LookupVariable(pcfg.AuthContext, d.Id())
+	if err != nil {
+		return diag.Errorf("failed to get variable `%s` from Airflow: %s", d.Id(), err)
+	}
+	if !found {
 		d.SetId("")
 		return nil
 	}
-	if err != nil {
-		return diag.Errorf("failed to get variable `%s`, Status: `%s` from Airflow: %s", d.Id(), resp.Status, err)
-	}
 
 	d.Set("key", variable.Key)
 	d.Set("value", variable.Value)
@@ -103,7 +99,6 @@ func resourceVariableRead(ctx context.Context, d *schema.ResourceData, m interfa
 
 func resourceVariableUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	pcfg := m.(ProviderConfig)
-	client := pcfg.ApiClient
 
 	val := d.Get("value").(string)
 	key := d.Id()
@@ -117,9 +112,9 @@ func resourceVariableUpdate(ctx context.Context, d *schema.ResourceData, m inter
 		variableReq.SetDescription(v.(string))
 	}
 
-	_, resp, err := client.VariableAPI.PatchVariable(pcfg.AuthContext, key).VariableBody(variableReq).Execute()
+	resp, err := pcfg.API.UpdateVariable(pcfg.AuthContext, key, variableReq)
 	if err != nil {
-		return diag.Errorf("failed to update variable `%s`, Status: `%s` from Airflow: %s", key, resp.Status, err)
+		return diag.Errorf("failed to update variable `%s`, Status: `%s` from Airflow: %s", key, responseStatus(resp), err)
 	}
 
 	return resourceVariableRead(ctx, d, m)
@@ -127,11 +122,10 @@ func resourceVariableUpdate(ctx context.Context, d *schema.ResourceData, m inter
 
 func resourceVariableDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	pcfg := m.(ProviderConfig)
-	client := pcfg.ApiClient
 
-	resp, err := client.VariableAPI.DeleteVariable(pcfg.AuthContext, d.Id()).Execute()
+	resp, err := pcfg.API.DeleteVariable(pcfg.AuthContext, d.Id())
 	if err != nil {
-		return diag.Errorf("failed to delete variable `%s`, Status: `%s` from Airflow: %s", d.Id(), resp.Status, err)
+		return diag.Errorf("failed to delete variable `%s`, Status: `%s` from Airflow: %s", d.Id(), responseStatus(resp), err)
 	}
 
 	if resp != nil && resp.StatusCode == 404 {
@@ -0,0 +1,82 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/gbloisi-openaire/airflow-client-go/airflow"
+	"golang.org/x/oauth2"
+)
+
+// restClient sends JSON requests to the endpoints the generated client does
+// not cover.
+type restClient struct {
+	httpClient *http.Client
+	basePath   string
+}
+
+// do sends a JSON request authenticated the same way as the generated client,
+// i.e. with the token source stored under airflow.ContextOAuth2, and decodes
+// the response into out. The response is returned even on error so callers
+// can inspect the status code.
+func (c *restClient) do(ctx context.Context, method, path string, query url.Values, body, out interface{}) (*http.Response, error) {
+	u := c.basePath + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	var reqBody io.Reader
+	if body != nil {
+		buf, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reqBody = bytes.NewReader(buf)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u, reqBody)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	if ts, ok := ctx.Value(airflow.ContextOAuth2).(oauth2.TokenSource); ok {
+		token, err := ts.Token()
+		if err != nil {
+			return nil, err
+		}
+		token.SetAuthHeader(req)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return resp, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return resp, err
+	}
+
+	if resp.StatusCode >= 300 {
+		return resp, fmt.Errorf("%s %s: %s %s", method, u, resp.Status, strings.TrimSpace(string(respBody)))
+	}
+
+	if out != nil && len(respBody) > 0 {
+		if err := json.Unmarshal(respBody, out); err != nil {
+			return resp, fmt.Errorf("failed to decode response of %s %s: %w", method, u, err)
+		}
+	}
+
+	return resp, nil
+}
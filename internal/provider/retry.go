@@ -0,0 +1,155 @@
+package provider
+
+import (
+	"crypto/tls"
+	"errors"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// retryTransport retries requests failing with a transient error, waiting
+// with an exponential backoff between minWait and maxWait, or for the
+// duration requested by a Retry-After header.
+type retryTransport struct {
+	next       http.RoundTripper
+	maxRetries int
+	minWait    time.Duration
+	maxWait    time.Duration
+}
+
+func retryLayer(maxRetries int, minWait, maxWait time.Duration) transportLayer {
+	return func(next http.RoundTripper) http.RoundTripper {
+		if maxRetries <= 0 {
+			return next
+		}
+
+		return &retryTransport{
+			next:       next,
+			maxRetries: maxRetries,
+			minWait:    minWait,
+			maxWait:    maxWait,
+		}
+	}
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// A request whose body cannot be replayed can only be sent once.
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return t.next.RoundTrip(req)
+	}
+
+	attemptReq := req
+	for attempt := 0; ; attempt++ {
+		resp, err := t.next.RoundTrip(attemptReq)
+
+		if attempt >= t.maxRetries || !shouldRetryRequest(req, resp, err) {
+			return resp, err
+		}
+
+		wait := t.backoff(attempt, resp)
+		if resp != nil {
+			log.Printf("[DEBUG] %s %s returned %s, retrying in %s (%d/%d)", req.Method, req.URL, resp.Status, wait, attempt+1, t.maxRetries)
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		} else {
+			log.Printf("[DEBUG] %s %s failed: %s, retrying in %s (%d/%d)", req.Method, req.URL, err, wait, attempt+1, t.maxRetries)
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+
+		attemptReq = req.Clone(req.Context())
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			attemptReq.Body = body
+		}
+	}
+}
+
+func (t *retryTransport) backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if wait, ok := retryAfter(resp); ok {
+			return min(wait, t.maxWait)
+		}
+	}
+
+	wait := t.minWait
+	for i := 0; i < attempt && wait < t.maxWait; i++ {
+		wait *= 2
+	}
+
+	return min(wait, t.maxWait)
+}
+
+// shouldRetryRequest tells whether a failed attempt can safely be sent again.
+// Idempotent requests are retried on any transient failure, while POST and
+// PATCH requests are only retried when the server did not process them: the
+// connection could not be established, or the server answered 429 or 503.
+func shouldRetryRequest(req *http.Request, resp *http.Response, err error) bool {
+	if err != nil {
+		if req.Context().Err() != nil {
+			return false
+		}
+
+		// A certificate problem will not go away by retrying.
+		var certErr *tls.CertificateVerificationError
+		if errors.As(err, &certErr) {
+			return false
+		}
+
+		var opErr *net.OpError
+		if errors.As(err, &opErr) && opErr.Op == "dial" {
+			return true
+		}
+
+		return isIdempotentMethod(req.Method)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		return true
+	case http.StatusBadGateway, http.StatusGatewayTimeout:
+		return isIdempotentMethod(req.Method)
+	}
+
+	return false
+}
+
+func isIdempotentMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+
+	return false
+}
+
+// retryAfter parses the Retry-After header, either in seconds or as a date.
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	v := resp.Header.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(v); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(v); err == nil {
+		return max(time.Until(date), 0), true
+	}
+
+	return 0, false
+}
@@ -0,0 +1,92 @@
+package provider
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRetryTransport_retriesTransientErrors(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != `{"key":"foo"}` {
+			t.Errorf("expected the body to be replayed, got %q", body)
+		}
+
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: retryLayer(4, time.Millisecond, 10*time.Millisecond)(http.DefaultTransport)}
+	resp, err := client.Post(server.URL, "application/json", bytes.NewBufferString(`{"key":"foo"}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected the request to eventually succeed, got %s", resp.Status)
+	}
+	if calls != 3 {
+		t.Fatalf("expected 3 attempts, got %d", calls)
+	}
+}
+
+func TestRetryTransport_doesNotRetryUnsafePost(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: retryLayer(2, time.Millisecond, 10*time.Millisecond)(http.DefaultTransport)}
+
+	resp, err := client.Post(server.URL, "application/json", bytes.NewBufferString(`{}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	resp.Body.Close()
+	if calls != 1 {
+		t.Fatalf("expected a POST answered with 502 not to be retried, got %d attempts", calls)
+	}
+
+	resp, err = client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadGateway {
+		t.Fatalf("expected the last response to be returned, got %s", resp.Status)
+	}
+	if calls != 4 {
+		t.Fatalf("expected a GET to be retried twice, got %d attempts", calls-1)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	resp := &http.Response{Header: http.Header{}}
+	if _, ok := retryAfter(resp); ok {
+		t.Fatal("expected no delay without header")
+	}
+
+	resp.Header.Set("Retry-After", "7")
+	if wait, ok := retryAfter(resp); !ok || wait != 7*time.Second {
+		t.Fatalf("expected 7s, got %s", wait)
+	}
+
+	resp.Header.Set("Retry-After", time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat))
+	if wait, ok := retryAfter(resp); !ok || wait != 0 {
+		t.Fatalf("expected no wait for a date in the past, got %s", wait)
+	}
+}
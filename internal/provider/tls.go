@@ -0,0 +1,84 @@
+package provider
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// providerTLSConfig builds the TLS configuration from the provider arguments.
+// It returns nil when none of them is set so the default one is used.
+func providerTLSConfig(d *schema.ResourceData) (*tls.Config, error) {
+	caCert, err := pemFromArguments(d, "ca_cert_pem", "ca_cert_file")
+	if err != nil {
+		return nil, err
+	}
+	clientCert, err := pemFromArguments(d, "client_cert_pem", "client_cert_file")
+	if err != nil {
+		return nil, err
+	}
+	clientKey, err := pemFromArguments(d, "client_key_pem", "client_key_file")
+	if err != nil {
+		return nil, err
+	}
+	insecure := d.Get("disable_ssl_verification").(bool)
+
+	if clientKey != nil && clientCert == nil {
+		return nil, fmt.Errorf("a client key requires a client certificate")
+	}
+
+	if caCert == nil && clientCert == nil && !insecure {
+		return nil, nil
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: insecure,
+	}
+
+	if caCert != nil {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(caCert) {
+			return nil, fmt.Errorf("no valid certificate found in the CA certificate")
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if clientCert != nil {
+		if clientKey == nil {
+			return nil, fmt.Errorf("a client certificate requires a client key")
+		}
+
+		cert, err := tls.X509KeyPair(clientCert, clientKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
+
+// pemFromArguments returns the PEM content of either the inline argument or
+// the file argument, or nil when neither is set.
+func pemFromArguments(d *schema.ResourceData, pemKey, fileKey string) ([]byte, error) {
+	if v, ok := d.GetOk(pemKey); ok {
+		return []byte(v.(string)), nil
+	}
+
+	if v, ok := d.GetOk(fileKey); ok {
+		content, err := os.ReadFile(v.(string))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", fileKey, err)
+		}
+		return content, nil
+	}
+
+	return nil, nil
+}
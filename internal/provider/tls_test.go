@@ -0,0 +1,97 @@
+package provider
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestProviderTLSConfig_caCert(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+	d := schema.TestResourceDataRaw(t, AirflowProvider("test").Schema, map[string]interface{}{
+		"base_endpoint": server.URL,
+		"ca_cert_pem":   string(caPEM),
+	})
+
+	tlsConfig, err := providerTLSConfig(d)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: tlsConfig}}
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("expected the server certificate to be trusted: %s", err)
+	}
+	resp.Body.Close()
+}
+
+func TestProviderTLSConfig_clientCert(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	// Reuse the test server key pair as client certificate.
+	keyPair := server.TLS.Certificates[0]
+	key, err := x509.MarshalPKCS8PrivateKey(keyPair.PrivateKey)
+	if err != nil {
+		t.Fatalf("failed to marshal key: %s", err)
+	}
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: keyPair.Certificate[0]})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: key})
+
+	d := schema.TestResourceDataRaw(t, AirflowProvider("test").Schema, map[string]interface{}{
+		"base_endpoint":   server.URL,
+		"client_cert_pem": string(certPEM),
+		"client_key_pem":  string(keyPEM),
+	})
+
+	tlsConfig, err := providerTLSConfig(d)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(tlsConfig.Certificates) != 1 {
+		t.Fatalf("expected one client certificate, got %d", len(tlsConfig.Certificates))
+	}
+
+	d = schema.TestResourceDataRaw(t, AirflowProvider("test").Schema, map[string]interface{}{
+		"base_endpoint":   server.URL,
+		"client_cert_pem": string(certPEM),
+	})
+
+	if _, err := providerTLSConfig(d); err == nil {
+		t.Fatal("expected an error for a client certificate without key")
+	}
+
+	d = schema.TestResourceDataRaw(t, AirflowProvider("test").Schema, map[string]interface{}{
+		"base_endpoint":  server.URL,
+		"client_key_pem": string(keyPEM),
+	})
+
+	if _, err := providerTLSConfig(d); err == nil {
+		t.Fatal("expected an error for a client key without certificate")
+	}
+}
+
+func TestProviderTLSConfig_default(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, AirflowProvider("test").Schema, map[string]interface{}{
+		"base_endpoint": "https://localhost:8080",
+	})
+
+	tlsConfig, err := providerTLSConfig(d)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if tlsConfig != nil {
+		t.Fatal("expected no custom TLS configuration")
+	}
+}
@@ -0,0 +1,164 @@
+package provider
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// tokenRefreshMargin is how long before the JWT expiry a new token is requested.
+const tokenRefreshMargin = time.Minute
+
+// renewableTokenSource is an oauth2.TokenSource able to drop a token the
+// server rejected, so that the next call obtains a new one.
+type renewableTokenSource interface {
+	oauth2.TokenSource
+	invalidate(rejected string)
+}
+
+// loginTokenSource is an oauth2.TokenSource that obtains a JWT through login
+// and transparently logs in again shortly before it expires or after the
+// server rejected it.
+type loginTokenSource struct {
+	mu    sync.Mutex
+	login func() (string, error)
+	token *oauth2.Token
+}
+
+func newLoginTokenSource(login func() (string, error)) *loginTokenSource {
+	return &loginTokenSource{login: login}
+}
+
+func (s *loginTokenSource) Token() (*oauth2.Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if tokenFresh(s.token) {
+		return s.token, nil
+	}
+
+	log.Printf("[DEBUG] Requesting a new Airflow API token")
+	accessToken, err := s.login()
+	if err != nil {
+		return nil, err
+	}
+
+	expiry, err := jwtExpiry(accessToken)
+	if err != nil {
+		log.Printf("[WARN] Unable to read the expiry of the Airflow API token, it will only be renewed when rejected: %s", err)
+	}
+
+	s.token = &oauth2.Token{
+		AccessToken: accessToken,
+		TokenType:   "Bearer",
+		Expiry:      expiry,
+	}
+
+	return s.token, nil
+}
+
+// invalidate drops the cached token if it is still the rejected one, so that
+// concurrent requests failing with the same token only trigger one login.
+func (s *loginTokenSource) invalidate(rejected string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token != nil && s.token.AccessToken == rejected {
+		s.token = nil
+	}
+}
+
+// tokenFresh reports whether token can be used without being renewed.
+func tokenFresh(token *oauth2.Token) bool {
+	return token != nil && (token.Expiry.IsZero() || time.Now().Add(tokenRefreshMargin).Before(token.Expiry))
+}
+
+// jwtExpiry returns the time encoded in the exp claim of a JWT, or the zero
+// time when the token has no such claim.
+func jwtExpiry(token string) (time.Time, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return time.Time{}, fmt.Errorf("token is not a JWT")
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to decode JWT payload: %w", err)
+	}
+
+	var claims struct {
+		Exp json.Number `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return time.Time{}, fmt.Errorf("failed to parse JWT claims: %w", err)
+	}
+
+	if claims.Exp == "" {
+		return time.Time{}, nil
+	}
+
+	exp, err := claims.Exp.Float64()
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid exp claim %q: %w", claims.Exp, err)
+	}
+
+	return time.Unix(int64(exp), 0), nil
+}
+
+// reauthTransport retries a request once with a fresh token when the API
+// answers 401 to a token issued by source.
+type reauthTransport struct {
+	base   http.RoundTripper
+	source renewableTokenSource
+}
+
+func (t *reauthTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+
+	rejected, ok := strings.CutPrefix(req.Header.Get("Authorization"), "Bearer ")
+	if !ok || (req.Body != nil && req.GetBody == nil) {
+		return resp, nil
+	}
+
+	// The login may go through the same transport, release the connection,
+	// and the slot of limitTransport, before logging in again.
+	body, readErr := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if readErr != nil {
+		return nil, readErr
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	t.source.invalidate(rejected)
+	token, tokenErr := t.source.Token()
+	if tokenErr != nil {
+		log.Printf("[WARN] Failed to renew the Airflow API token after a 401: %s", tokenErr)
+		return resp, nil
+	}
+
+	retry := req.Clone(req.Context())
+	if req.GetBody != nil {
+		body, bodyErr := req.GetBody()
+		if bodyErr != nil {
+			return resp, nil
+		}
+		retry.Body = body
+	}
+	token.SetAuthHeader(retry)
+
+	log.Printf("[DEBUG] Airflow API token rejected, retrying %s %s with a new token", req.Method, req.URL)
+
+	return t.base.RoundTrip(retry)
+}
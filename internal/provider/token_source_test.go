@@ -0,0 +1,177 @@
+package provider
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func testJWT(exp time.Time, id int) string {
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none","typ":"JWT"}`))
+	payload := base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf(`{"sub":"%d","exp":%d}`, id, exp.Unix())))
+
+	return header + "." + payload + ".sig"
+}
+
+func TestJwtExpiry(t *testing.T) {
+	exp := time.Now().Add(time.Hour).Truncate(time.Second)
+
+	got, err := jwtExpiry(testJWT(exp, 1))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !got.Equal(exp) {
+		t.Fatalf("expected expiry %s, got %s", exp, got)
+	}
+
+	if _, err := jwtExpiry("not-a-jwt"); err == nil {
+		t.Fatal("expected an error for a malformed token")
+	}
+}
+
+func TestLoginTokenSource_refreshesBeforeExpiry(t *testing.T) {
+	var logins int32
+	source := newLoginTokenSource(func() (string, error) {
+		n := atomic.AddInt32(&logins, 1)
+		if n == 1 {
+			// The first token is already inside the refresh margin.
+			return testJWT(time.Now().Add(tokenRefreshMargin/2), int(n)), nil
+		}
+		return testJWT(time.Now().Add(time.Hour), int(n)), nil
+	})
+
+	first, err := source.Token()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	second, err := source.Token()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	third, err := source.Token()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if first.AccessToken == second.AccessToken {
+		t.Fatal("expected a token close to its expiry to be renewed")
+	}
+	if second.AccessToken != third.AccessToken {
+		t.Fatal("expected a valid token to be reused")
+	}
+	if logins != 2 {
+		t.Fatalf("expected 2 logins, got %d", logins)
+	}
+}
+
+func TestReauthTransport_retriesOnUnauthorized(t *testing.T) {
+	exp := time.Now().Add(time.Hour)
+	var logins int32
+	source := newLoginTokenSource(func() (string, error) {
+		n := atomic.AddInt32(&logins, 1)
+		return testJWT(exp, int(n)), nil
+	})
+
+	// The server only accepts the second token, as if the first one had been revoked.
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+testJWT(exp, 2) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: &reauthTransport{base: http.DefaultTransport, source: source}}
+
+	token, err := source.Token()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	token.SetAuthHeader(req)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected the request to succeed after a new login, got %s", resp.Status)
+	}
+	if logins != 2 {
+		t.Fatalf("expected 2 logins, got %d", logins)
+	}
+}
+
+func TestReauthTransport_loginWithinConcurrencyLimit(t *testing.T) {
+	exp := time.Now().Add(time.Hour)
+	var logins int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/login" {
+			n := atomic.AddInt32(&logins, 1)
+			w.Write([]byte(testJWT(exp, int(n))))
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer "+testJWT(exp, 2) {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"detail":"Unauthorized"}`))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	// The login shares the single slot of the API requests.
+	limited := limitLayer(0, 1)(http.DefaultTransport)
+	loginClient := &http.Client{Transport: limited}
+	source := newLoginTokenSource(func() (string, error) {
+		resp, err := loginClient.Get(server.URL + "/login")
+		if err != nil {
+			return "", err
+		}
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		return string(body), err
+	})
+	client := &http.Client{Transport: reauthLayer(source)(limited)}
+
+	done := make(chan error, 1)
+	go func() {
+		token, err := source.Token()
+		if err != nil {
+			done <- err
+			return
+		}
+		req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+		token.SetAuthHeader(req)
+
+		resp, err := client.Do(req)
+		if err != nil {
+			done <- err
+			return
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			done <- fmt.Errorf("expected the request to succeed after a new login, got %s", resp.Status)
+			return
+		}
+		done <- nil
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("the login after a 401 waited for the slot of the rejected request")
+	}
+}
@@ -0,0 +1,139 @@
+package provider
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/logging"
+)
+
+// transportLayer wraps a RoundTripper with an additional behaviour.
+type transportLayer func(http.RoundTripper) http.RoundTripper
+
+// newBaseTransport returns a transport with the same proxy, timeout and
+// connection pooling defaults as http.DefaultTransport, using tlsConfig when
+// it is not nil.
+func newBaseTransport(tlsConfig *tls.Config) *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if tlsConfig != nil {
+		transport.TLSClientConfig = tlsConfig
+	}
+
+	return transport
+}
+
+// chainTransport applies layers to base in order, so the last layer is the
+// first to see a request.
+func chainTransport(base http.RoundTripper, layers ...transportLayer) http.RoundTripper {
+	transport := base
+	for _, layer := range layers {
+		transport = layer(transport)
+	}
+
+	return transport
+}
+
+// loggingLayer traces requests and responses when TF_LOG is enabled.
+func loggingLayer(next http.RoundTripper) http.RoundTripper {
+	return logging.NewLoggingHTTPTransport(next)
+}
+
+// reauthLayer renews the token of source when a request is rejected with 401.
+func reauthLayer(source renewableTokenSource) transportLayer {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return &reauthTransport{
+			base:   next,
+			source: source,
+		}
+	}
+}
+
+// basicAuthLayer sends username and password as HTTP basic auth with every
+// request. Like headerLayer, it must sit below loggingLayer.
+func basicAuthLayer(username, password string) transportLayer {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return &basicAuthTransport{
+			base:     next,
+			username: username,
+			password: password,
+		}
+	}
+}
+
+type basicAuthTransport struct {
+	base     http.RoundTripper
+	username string
+	password string
+}
+
+func (t *basicAuthTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.SetBasicAuth(t.username, t.password)
+
+	return t.base.RoundTrip(req)
+}
+
+// userAgent returns the User-Agent identifying the provider, and Terraform
+// when its version is known.
+func userAgent(providerVersion, terraformVersion string) string {
+	ua := "terraform-provider-airflow/" + providerVersion
+	if terraformVersion != "" {
+		ua += " terraform/" + terraformVersion
+	}
+
+	return ua
+}
+
+// headerLayer sets the User-Agent, unless empty, and headers on every
+// request to Airflow. headers come last so they can override the User-Agent.
+// It sits below loggingLayer so that header values, often API keys, are not
+// logged.
+func headerLayer(userAgent string, headers map[string]string) transportLayer {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return &headerTransport{
+			base:      next,
+			userAgent: userAgent,
+			headers:   headers,
+		}
+	}
+}
+
+type headerTransport struct {
+	base      http.RoundTripper
+	userAgent string
+	headers   map[string]string
+}
+
+func (t *headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Context().Value(externalRequestKey{}) != nil {
+		return t.base.RoundTrip(req)
+	}
+
+	req = req.Clone(req.Context())
+	if t.userAgent != "" {
+		req.Header.Set("User-Agent", t.userAgent)
+	}
+	for k, v := range t.headers {
+		req.Header.Set(k, v)
+	}
+
+	return t.base.RoundTrip(req)
+}
+
+type externalRequestKey struct{}
+
+// externalLayer marks requests to servers other than Airflow, such as an
+// OAuth2 token endpoint, so that headerLayer leaves them untouched while they
+// still share the limits and retries of the transport.
+func externalLayer(next http.RoundTripper) http.RoundTripper {
+	return &externalTransport{base: next}
+}
+
+type externalTransport struct {
+	base http.RoundTripper
+}
+
+func (t *externalTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return t.base.RoundTrip(req.WithContext(context.WithValue(req.Context(), externalRequestKey{}, true)))
+}
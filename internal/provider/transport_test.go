@@ -0,0 +1,184 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gbloisi-openaire/airflow-client-go/airflow"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"golang.org/x/oauth2"
+)
+
+func TestNewBaseTransport(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, AirflowProvider("test").Schema, map[string]interface{}{
+		"base_endpoint":            "https://localhost:8080",
+		"disable_ssl_verification": true,
+	})
+
+	tlsConfig, err := providerTLSConfig(d)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	transport := newBaseTransport(tlsConfig)
+	if transport.Proxy == nil {
+		t.Fatal("expected the proxy settings of the environment to be honoured")
+	}
+	if transport.TLSHandshakeTimeout == 0 {
+		t.Fatal("expected the default TLS handshake timeout")
+	}
+	if !transport.TLSClientConfig.InsecureSkipVerify {
+		t.Fatal("expected SSL verification to be disabled")
+	}
+}
+
+func TestChainTransport_order(t *testing.T) {
+	var calls []string
+	layer := func(name string) transportLayer {
+		return func(next http.RoundTripper) http.RoundTripper {
+			return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				calls = append(calls, name)
+				return next.RoundTrip(req)
+			})
+		}
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	client := &http.Client{Transport: chainTransport(http.DefaultTransport, layer("inner"), layer("outer"))}
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	resp.Body.Close()
+
+	if len(calls) != 2 || calls[0] != "outer" || calls[1] != "inner" {
+		t.Fatalf("expected outer then inner layer, got %v", calls)
+	}
+}
+
+func TestProviderConfigure_disableSSLVerification(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"version":"3.0.2","git_version":null}`))
+	}))
+	defer server.Close()
+
+	d := schema.TestResourceDataRaw(t, AirflowProvider("test").Schema, map[string]interface{}{
+		"base_endpoint":            server.URL,
+		"oauth2_token":             "token",
+		"disable_ssl_verification": true,
+	})
+
+	meta, diags := providerConfigure(context.Background(), d, "")
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	pcfg := meta.(ProviderConfig)
+
+	version, _, err := pcfg.ApiClient.VersionAPI.GetVersion(pcfg.AuthContext).Execute()
+	if err != nil {
+		t.Fatalf("expected the self-signed certificate to be accepted: %s", err)
+	}
+	if version.Version != "3.0.2" {
+		t.Fatalf("unexpected version %q", version.Version)
+	}
+}
+
+func TestProviderConfigure_headers(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if ua := r.Header.Get("User-Agent"); ua != "terraform-provider-airflow/1.2.3 terraform/1.9.0" {
+			t.Errorf("unexpected User-Agent %q on %s", ua, r.URL.Path)
+		}
+		if tenant := r.Header.Get("X-Tenant"); tenant != "acme" {
+			t.Errorf("expected the X-Tenant header on %s, got %q", r.URL.Path, tenant)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v2/variables":
+			w.Write([]byte(`{"variables":[{"key":"example","value":"value","description":null,"is_encrypted":false}],"total_entries":1}`))
+		default:
+			w.Write([]byte(`{"roles":[],"total_entries":0}`))
+		}
+	}))
+	defer server.Close()
+
+	d := schema.TestResourceDataRaw(t, AirflowProvider("test").Schema, map[string]interface{}{
+		"base_endpoint": server.URL,
+		"oauth2_token":  "token",
+		"api_version":   "v2",
+		"headers": map[string]interface{}{
+			"X-Tenant": "acme",
+		},
+	})
+	meta, diags := providerConfigure(context.Background(), d, userAgent("1.2.3", "1.9.0"))
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	pcfg := meta.(ProviderConfig)
+
+	// Through the generated client and through the FAB client.
+	if _, _, err := pcfg.API.LookupVariable(pcfg.AuthContext, "example"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, err := pcfg.FabClient.do(pcfg.AuthContext, http.MethodGet, "/roles", nil, nil, nil); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if requests != 2 {
+		t.Fatalf("expected 2 requests, got %d", requests)
+	}
+}
+
+func TestProviderConfigure_headersNotSentToTokenEndpoint(t *testing.T) {
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if tenant := r.Header.Get("X-Tenant"); tenant != "" {
+			t.Errorf("expected no X-Tenant header on the token endpoint, got %q", tenant)
+		}
+		if ua := r.Header.Get("User-Agent"); strings.HasPrefix(ua, "terraform-provider-airflow/") {
+			t.Errorf("expected the User-Agent of the OAuth2 client, got %q", ua)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"access_token":"machine-token","token_type":"Bearer","expires_in":3600}`))
+	}))
+	defer tokenServer.Close()
+
+	d := schema.TestResourceDataRaw(t, AirflowProvider("test").Schema, map[string]interface{}{
+		"base_endpoint":        "http://localhost:8080",
+		"oauth2_client_id":     "client",
+		"oauth2_client_secret": "secret",
+		"oauth2_token_url":     tokenServer.URL + "/token",
+		"api_version":          "v2",
+		"headers": map[string]interface{}{
+			"X-Tenant": "acme",
+		},
+	})
+	meta, diags := providerConfigure(context.Background(), d, userAgent("1.2.3", "1.9.0"))
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	ts := meta.(ProviderConfig).AuthContext.Value(airflow.ContextOAuth2).(oauth2.TokenSource)
+	if _, err := ts.Token(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
+
+func TestUserAgent(t *testing.T) {
+	if ua := userAgent("1.2.3", ""); ua != "terraform-provider-airflow/1.2.3" {
+		t.Fatalf("expected no Terraform version when unknown, got %q", ua)
+	}
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
@@ -0,0 +1,225 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gbloisi-openaire/airflow-client-go/airflow"
+)
+
+// bulkWindow is how long the first write of a batch waits for concurrent
+// writes of the same kind to join it.
+const bulkWindow = 100 * time.Millisecond
+
+const (
+	bulkActionCreate = "create"
+	bulkActionUpdate = "update"
+	bulkActionDelete = "delete"
+)
+
+// writeBatcher coalesces the concurrent writes of variables, connections and
+// pools into the bulk endpoints of the Airflow API.
+type writeBatcher struct {
+	variables   *bulkWriter[airflow.VariableBody]
+	connections *bulkWriter[airflow.ConnectionBody]
+	pools       *bulkWriter[airflow.PoolBody]
+}
+
+// bulkWriter collects writes during bulkWindow and sends them as a single
+// bulk request, then hands every write its own result. Creates fail on
+// existing entities and updates on missing ones, so that callers can fall
+// back to their single entity logic for anything the batch did not apply.
+type bulkWriter[T any] struct {
+	mu      sync.Mutex
+	pending []*bulkWrite[T]
+	send    func(ctx context.Context, creates, updates []T, deletes []string) (*airflow.BulkResponse, error)
+}
+
+type bulkWrite[T any] struct {
+	action string
+	key    string
+	entity T
+	result chan error
+}
+
+func newWriteBatcher(client *airflow.APIClient) *writeBatcher {
+	return &writeBatcher{
+		variables: &bulkWriter[airflow.VariableBody]{
+			send: func(ctx context.Context, creates, updates []airflow.VariableBody, deletes []string) (*airflow.BulkResponse, error) {
+				var actions []airflow.BulkBodyVariableBodyActionsInner
+				if len(creates) > 0 {
+					action := airflow.NewBulkCreateActionVariableBody(bulkActionCreate, creates)
+					action.SetActionOnExistence(airflow.BULKACTIONONEXISTENCE_FAIL)
+					actions = append(actions, airflow.BulkCreateActionVariableBodyAsBulkBodyVariableBodyActionsInner(action))
+				}
+				if len(updates) > 0 {
+					action := airflow.NewBulkUpdateActionVariableBody(bulkActionUpdate, updates)
+					action.SetActionOnNonExistence(airflow.BULKACTIONNOTONEXISTENCE_FAIL)
+					actions = append(actions, airflow.BulkUpdateActionVariableBodyAsBulkBodyVariableBodyActionsInner(action))
+				}
+				if len(deletes) > 0 {
+					action := airflow.NewBulkDeleteActionVariableBody(bulkActionDelete, deletes)
+					action.SetActionOnNonExistence(airflow.BULKACTIONNOTONEXISTENCE_SKIP)
+					actions = append(actions, airflow.BulkDeleteActionVariableBodyAsBulkBodyVariableBodyActionsInner(action))
+				}
+
+				resp, _, err := client.VariableAPI.BulkVariables(ctx).BulkBodyVariableBody(*airflow.NewBulkBodyVariableBody(actions)).Execute()
+				return resp, err
+			},
+		},
+		connections: &bulkWriter[airflow.ConnectionBody]{
+			send: func(ctx context.Context, creates, updates []airflow.ConnectionBody, deletes []string) (*airflow.BulkResponse, error) {
+				var actions []airflow.BulkBodyConnectionBodyActionsInner
+				if len(creates) > 0 {
+					action := airflow.NewBulkCreateActionConnectionBody(bulkActionCreate, creates)
+					action.SetActionOnExistence(airflow.BULKACTIONONEXISTENCE_FAIL)
+					actions = append(actions, airflow.BulkCreateActionConnectionBodyAsBulkBodyConnectionBodyActionsInner(action))
+				}
+				if len(updates) > 0 {
+					action := airflow.NewBulkUpdateActionConnectionBody(bulkActionUpdate, updates)
+					action.SetActionOnNonExistence(airflow.BULKACTIONNOTONEXISTENCE_FAIL)
+					actions = append(actions, airflow.BulkUpdateActionConnectionBodyAsBulkBodyConnectionBodyActionsInner(action))
+				}
+				if len(deletes) > 0 {
+					action := airflow.NewBulkDeleteActionConnectionBody(bulkActionDelete, deletes)
+					action.SetActionOnNonExistence(airflow.BULKACTIONNOTONEXISTENCE_SKIP)
+					actions = append(actions, airflow.BulkDeleteActionConnectionBodyAsBulkBodyConnectionBodyActionsInner(action))
+				}
+
+				resp, _, err := client.ConnectionAPI.BulkConnections(ctx).BulkBodyConnectionBody(*airflow.NewBulkBodyConnectionBody(actions)).Execute()
+				return resp, err
+			},
+		},
+		pools: &bulkWriter[airflow.PoolBody]{
+			send: func(ctx context.Context, creates, updates []airflow.PoolBody, deletes []string) (*airflow.BulkResponse, error) {
+				var actions []airflow.BulkBodyPoolBodyActionsInner
+				if len(creates) > 0 {
+					action := airflow.NewBulkCreateActionPoolBody(bulkActionCreate, creates)
+					action.SetActionOnExistence(airflow.BULKACTIONONEXISTENCE_FAIL)
+					actions = append(actions, airflow.BulkCreateActionPoolBodyAsBulkBodyPoolBodyActionsInner(action))
+				}
+				if len(updates) > 0 {
+					action := airflow.NewBulkUpdateActionPoolBody(bulkActionUpdate, updates)
+					action.SetActionOnNonExistence(airflow.BULKACTIONNOTONEXISTENCE_FAIL)
+					actions = append(actions, airflow.BulkUpdateActionPoolBodyAsBulkBodyPoolBodyActionsInner(action))
+				}
+				if len(deletes) > 0 {
+					action := airflow.NewBulkDeleteActionPoolBody(bulkActionDelete, deletes)
+					action.SetActionOnNonExistence(airflow.BULKACTIONNOTONEXISTENCE_SKIP)
+					actions = append(actions, airflow.BulkDeleteActionPoolBodyAsBulkBodyPoolBodyActionsInner(action))
+				}
+
+				resp, _, err := client.PoolAPI.BulkPools(ctx).BulkBodyPoolBody(*airflow.NewBulkBodyPoolBody(actions)).Execute()
+				return resp, err
+			},
+		},
+	}
+}
+
+func (b *bulkWriter[T]) create(ctx context.Context, key string, entity T) error {
+	return b.submit(ctx, bulkActionCreate, key, entity)
+}
+
+func (b *bulkWriter[T]) update(ctx context.Context, key string, entity T) error {
+	return b.submit(ctx, bulkActionUpdate, key, entity)
+}
+
+func (b *bulkWriter[T]) delete(ctx context.Context, key string) error {
+	var zero T
+	return b.submit(ctx, bulkActionDelete, key, zero)
+}
+
+// submit queues a write and waits for the result of its batch.
+func (b *bulkWriter[T]) submit(ctx context.Context, action, key string, entity T) error {
+	write := &bulkWrite[T]{
+		action: action,
+		key:    key,
+		entity: entity,
+		result: make(chan error, 1),
+	}
+
+	b.mu.Lock()
+	b.pending = append(b.pending, write)
+	if len(b.pending) == 1 {
+		time.AfterFunc(bulkWindow, func() { b.flush(ctx) })
+	}
+	b.mu.Unlock()
+
+	select {
+	case err := <-write.result:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (b *bulkWriter[T]) flush(ctx context.Context) {
+	b.mu.Lock()
+	writes := b.pending
+	b.pending = nil
+	b.mu.Unlock()
+
+	var creates, updates []T
+	var deletes []string
+	for _, w := range writes {
+		switch w.action {
+		case bulkActionCreate:
+			creates = append(creates, w.entity)
+		case bulkActionUpdate:
+			updates = append(updates, w.entity)
+		case bulkActionDelete:
+			deletes = append(deletes, w.key)
+		}
+	}
+
+	log.Printf("[DEBUG] Sending a bulk request with %d creates, %d updates and %d deletes", len(creates), len(updates), len(deletes))
+	resp, err := b.send(ctx, creates, updates, deletes)
+
+	for _, w := range writes {
+		if err != nil {
+			w.result <- fmt.Errorf("bulk request failed: %w", err)
+			continue
+		}
+
+		var result airflow.NullableBulkActionResponse
+		switch w.action {
+		case bulkActionCreate:
+			result = resp.Create
+		case bulkActionUpdate:
+			result = resp.Update
+		case bulkActionDelete:
+			result = resp.Delete
+		}
+		w.result <- bulkWriteResult(result.Get(), w.key)
+	}
+}
+
+// bulkWriteResult tells whether the entity identified by key was applied.
+// Entities without errors in their action count as applied even when they are
+// not listed as succeeded, e.g. deletes skipped because already gone.
+func bulkWriteResult(result *airflow.BulkActionResponse, key string) error {
+	if result == nil {
+		return fmt.Errorf("bulk response has no result for `%s`", key)
+	}
+
+	for _, success := range result.Success {
+		if success == key {
+			return nil
+		}
+	}
+
+	if len(result.Errors) == 0 {
+		return nil
+	}
+
+	messages := make([]string, 0, len(result.Errors))
+	for _, e := range result.Errors {
+		messages = append(messages, fmt.Sprintf("%v (status %v)", e["error"], e["status_code"]))
+	}
+
+	return fmt.Errorf("bulk request did not apply `%s`: %s", key, strings.Join(messages, "; "))
+}
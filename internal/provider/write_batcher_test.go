@@ -0,0 +1,150 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/gbloisi-openaire/airflow-client-go/airflow"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestWriteBatcher_variables(t *testing.T) {
+	var mu sync.Mutex
+	var bulkCalls int
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch || r.URL.Path != "/api/v2/variables" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			return
+		}
+
+		mu.Lock()
+		bulkCalls++
+		mu.Unlock()
+
+		var body struct {
+			Actions []struct {
+				Action   string            `json:"action"`
+				Entities []json.RawMessage `json:"entities"`
+			} `json:"actions"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("failed to decode bulk body: %s", err)
+		}
+
+		resp := map[string]interface{}{}
+		for _, action := range body.Actions {
+			switch action.Action {
+			case "create":
+				// Creating var_3 conflicts, which fails the whole action.
+				resp["create"] = map[string]interface{}{
+					"success": []string{},
+					"errors":  []map[string]interface{}{{"error": "The variables with these keys: {'var_3'} already exist.", "status_code": 409}},
+				}
+			case "delete":
+				resp["delete"] = map[string]interface{}{"success": []string{}, "errors": []interface{}{}}
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	d := schema.TestResourceDataRaw(t, AirflowProvider("test").Schema, map[string]interface{}{
+		"base_endpoint": server.URL,
+		"oauth2_token":  "token",
+		"api_version":   "v2",
+	})
+	meta, diags := providerConfigure(context.Background(), d, "")
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	pcfg := meta.(ProviderConfig)
+
+	var wg sync.WaitGroup
+	errs := make([]error, 5)
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			key := fmt.Sprintf("var_%d", i)
+			if i == 0 {
+				errs[i] = pcfg.API.(*apiV2).batcher.variables.delete(pcfg.AuthContext, key)
+				return
+			}
+			errs[i] = pcfg.API.(*apiV2).batcher.variables.create(pcfg.AuthContext, key, airflow.VariableBody{Key: key, Value: "value"})
+		}(i)
+	}
+	wg.Wait()
+
+	if bulkCalls != 1 {
+		t.Fatalf("expected the writes to share a single bulk request, got %d", bulkCalls)
+	}
+	if errs[0] != nil {
+		t.Fatalf("expected the delete of a missing variable to succeed, got %s", errs[0])
+	}
+	for i := 1; i < len(errs); i++ {
+		if errs[i] == nil {
+			t.Fatalf("expected var_%d to be reported as not created", i)
+		}
+	}
+}
+
+func TestBulkWriteResult(t *testing.T) {
+	result := airflow.NewBulkActionResponse()
+	result.SetSuccess([]string{"a"})
+	result.SetErrors([]map[string]interface{}{{"error": "boom", "status_code": 400}})
+
+	if err := bulkWriteResult(result, "a"); err != nil {
+		t.Fatalf("expected a to be applied, got %s", err)
+	}
+	if err := bulkWriteResult(result, "b"); err == nil {
+		t.Fatal("expected b to be reported as not applied")
+	}
+	if err := bulkWriteResult(nil, "a"); err == nil {
+		t.Fatal("expected an error without result")
+	}
+}
+
+func TestAPIV2_updateConnectionWithoutPassword(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch || r.URL.Path != "/api/v2/connections/example" {
+			t.Errorf("expected a single connection update, got %s %s", r.Method, r.URL.Path)
+		}
+
+		body := map[string]interface{}{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("failed to decode connection body: %s", err)
+		}
+		if _, ok := body["password"]; ok {
+			t.Errorf("expected the password to be left out, got %v", body["password"])
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"connection_id":"example","conn_type":"http","description":null,"host":"example.com","login":null,"schema":null,"port":null,"password":"***","extra":null}`))
+	}))
+	defer server.Close()
+
+	d := schema.TestResourceDataRaw(t, AirflowProvider("test").Schema, map[string]interface{}{
+		"base_endpoint": server.URL,
+		"oauth2_token":  "token",
+		"api_version":   "v2",
+	})
+	meta, diags := providerConfigure(context.Background(), d, "")
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	pcfg := meta.(ProviderConfig)
+
+	conn := airflow.NewConnectionBody("example", "http")
+	conn.SetHost("example.com")
+	if _, err := pcfg.API.UpdateConnection(pcfg.AuthContext, "example", *conn); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
@@ -8,6 +8,9 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
 )
 
+// version is set at build time by goreleaser.
+var version = "dev"
+
 func main() {
 	var debug bool
 
@@ -16,7 +19,7 @@ func main() {
 
 	plugin.Serve(&plugin.ServeOpts{
 		ProviderFunc: func() *schema.Provider {
-			return provider.AirflowProvider()
+			return provider.AirflowProvider(version)
 		},
 		ProviderAddr: "registry.terraform.io/drfaust92/airflow",
 		Debug:        debug,